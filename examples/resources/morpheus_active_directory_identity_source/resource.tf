@@ -13,8 +13,13 @@ resource "morpheus_active_directory_identity_source" "addemo" {
 
   role_mapping {
     role_id                     = 2
-    role_name                   = "developers"
     active_directory_group_name = "developers"
     active_directory_group_fqn  = "CN=developers,CN=Users,DC=contoso,DC=com"
   }
+
+  role_mapping {
+    role_name                   = "operators"
+    active_directory_group_name = "operators"
+    active_directory_group_fqn  = "CN=operators,CN=Users,DC=contoso,DC=com"
+  }
 }
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_app.tf_example_app 1
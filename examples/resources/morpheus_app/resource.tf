@@ -0,0 +1,21 @@
+resource "morpheus_app" "tf_example_app" {
+  name         = "tf-example-app"
+  description  = "Terraform app example"
+  blueprint_id = morpheus_terraform_app_blueprint.tfapp_blueprint.id
+  group_id     = 1
+  cloud_id     = 1
+  environment  = "dev"
+
+  tier {
+    name = "Web"
+    config = jsonencode({
+      instances = [
+        {
+          config = {
+            resourcePoolId = 5
+          }
+        }
+      ]
+    })
+  }
+}
@@ -0,0 +1 @@
+terraform import morpheus_azure_ad_identity_source.tf_example_azure_ad_identity_source 1
\ No newline at end of file
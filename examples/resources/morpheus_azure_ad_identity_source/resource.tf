@@ -0,0 +1,19 @@
+resource "morpheus_azure_ad_identity_source" "tf_example_azure_ad_identity_source" {
+  tenant_id               = 1
+  name                    = "azureaddemo"
+  description             = "TF example Azure AD identity source"
+  login_url               = "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/saml2"
+  logout_url              = "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/saml2"
+  application_id          = "11111111-1111-1111-1111-111111111111"
+  application_secret      = "Secret123"
+  required_group_id       = "22222222-2222-2222-2222-222222222222"
+  public_key              = file("${path.module}/azuread.crt")
+  default_account_role_id = 7
+
+  role_mapping {
+    role_id    = 2
+    role_name  = "developers"
+    group_name = "developers"
+    group_fqn  = "33333333-3333-3333-3333-333333333333"
+  }
+}
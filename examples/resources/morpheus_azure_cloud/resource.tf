@@ -0,0 +1,23 @@
+resource "morpheus_azure_cloud" "tf_example_azure_cloud" {
+  name                       = "tf-azure-demo"
+  code                       = "tf-azure-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  azure_cloud_type           = "global"
+  subscription_id            = "a3e6a1c8-3c2f-4a7e-9a8f-1f0f6c7e2b11"
+  azure_tenant_id            = "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+  client_id                  = "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+  client_secret              = "jS8Q~cJ2pW1n.mE4Yr7bT9xK3zL6vF0dH5gA"
+  region                     = "eastus"
+  resource_group             = "all"
+  inventory                  = "full"
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  datacenter_id              = "tfazuredemo"
+  guidance                   = "manual"
+  costing                    = "full"
+  agent_install_mode         = "cloudInit"
+}
@@ -0,0 +1,24 @@
+data "morpheus_credential" "azure_credentials" {
+  name = "azuredemo"
+}
+
+resource "morpheus_azure_cloud" "tf_example_azure_cloud" {
+  name                       = "tf-azure-demo"
+  code                       = "tf-azure-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  subscription_id            = "a3e6a1c8-3c2f-4a7e-9a8f-1f0f6c7e2b11"
+  azure_tenant_id            = "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+  credential_id              = data.morpheus_credential.azure_credentials.id
+  region                     = "eastus"
+  resource_group             = "morpheus-demo-rg"
+  inventory                  = "basic"
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  guidance                   = "manual"
+  costing                    = "costing"
+  agent_install_mode         = "ssh"
+}
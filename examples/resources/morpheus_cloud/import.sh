@@ -0,0 +1 @@
+terraform import morpheus_cloud.tf_example_cloud 1
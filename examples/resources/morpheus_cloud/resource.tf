@@ -0,0 +1,20 @@
+resource "morpheus_cloud" "tf_example_cloud" {
+  name        = "tf-nutanix-demo"
+  type        = "nutanix"
+  code        = "tf-nutanix-demo"
+  location    = "colorado"
+  description = "Nutanix Prism cloud managed by terraform"
+  visibility  = "private"
+  enabled     = true
+
+  config = {
+    apiUrl          = "https://prism.morpheus.local:9440"
+    username        = "admin"
+    importExisting  = "off"
+    diskStorageType = "thin"
+  }
+
+  sensitive_config = {
+    password = var.nutanix_password
+  }
+}
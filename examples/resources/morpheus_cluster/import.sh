@@ -0,0 +1 @@
+terraform import morpheus_cluster.tf_example_cluster 1
@@ -0,0 +1,18 @@
+resource "morpheus_cluster" "tf_example_cluster" {
+  name             = "tf-example-cluster"
+  description      = "Terraform cluster example"
+  cluster_type     = "kubernetes-cluster"
+  layout_id        = morpheus_cluster_layout.example_kubernetes_layout.id
+  cloud_id         = 1
+  group_id         = 1
+  plan_id          = 10
+  worker_plan_id   = 11
+  worker_count     = 3
+  resource_pool_id = 5
+  network_id       = 12
+}
+
+output "kubeconfig" {
+  value     = morpheus_cluster.tf_example_cluster.kubeconfig
+  sensitive = true
+}
@@ -0,0 +1,19 @@
+resource "morpheus_gcp_cloud" "tf_example_gcp_cloud" {
+  name                       = "tf-gcp-demo"
+  code                       = "tf-gcp-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  project_id                 = "morpheus-demo"
+  region                     = "us-central1"
+  service_account_json       = file("${path.module}/morpheus-demo-sa.json")
+  inventory                  = "full"
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  datacenter_id              = "tfgcpdemo"
+  guidance                   = "manual"
+  costing                    = "full"
+  agent_install_mode         = "cloudInit"
+}
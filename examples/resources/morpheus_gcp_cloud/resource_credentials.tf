@@ -0,0 +1,22 @@
+data "morpheus_credential" "gcp_credentials" {
+  name = "gcpdemo"
+}
+
+resource "morpheus_gcp_cloud" "tf_example_gcp_cloud" {
+  name                       = "tf-gcp-demo"
+  code                       = "tf-gcp-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  project_id                 = "morpheus-demo"
+  region                     = "us-central1"
+  credential_id              = data.morpheus_credential.gcp_credentials.id
+  inventory                  = "basic"
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  guidance                   = "manual"
+  costing                    = "costing"
+  agent_install_mode         = "ssh"
+}
@@ -0,0 +1,66 @@
+data "morpheus_group" "morpheus_lab" {
+  name = "MORPHEUS"
+}
+
+data "morpheus_cloud" "morpheus_aws" {
+  name = "MORPHEUSAWS"
+}
+
+data "morpheus_instance_type" "ubuntu" {
+  name = "Ubuntu"
+}
+
+data "morpheus_instance_layout" "ubuntu" {
+  name = "Amazon Ubuntu 22.04"
+}
+
+data "morpheus_network" "subnet" {
+  name = "morpheus-subnet-a"
+}
+
+data "morpheus_plan" "aws" {
+  name = "T3 Small - 2 Core, 2GB Memory"
+}
+
+resource "morpheus_instance" "tf_example_instance" {
+  name               = "tfaws"
+  description        = "Terraform instance example"
+  cloud_id           = data.morpheus_cloud.morpheus_aws.id
+  group_id           = data.morpheus_group.morpheus_lab.id
+  instance_type_id   = data.morpheus_instance_type.ubuntu.id
+  instance_layout_id = data.morpheus_instance_layout.ubuntu.id
+  plan_id            = data.morpheus_plan.aws.id
+  environment        = "dev"
+
+  # changing the plan resizes the instance, which may reboot it
+  allow_reboot_on_resize = true
+  labels             = ["demo", "terraform"]
+
+  config = {
+    resourcePoolId   = "pool-vpc-0a1b2c3d"
+    availabilityZone = "us-east-1a"
+    securityGroup    = "sg-0a1b2c3d4e5f"
+  }
+
+  interfaces {
+    network_id = data.morpheus_network.subnet.id
+  }
+
+  volumes {
+    root         = true
+    name         = "root"
+    size         = 20
+    storage_type = 7
+  }
+
+  tags = {
+    name = "ubuntutf"
+  }
+
+  evar {
+    name   = "application"
+    value  = "demo"
+    export = true
+    masked = true
+  }
+}
@@ -0,0 +1,6 @@
+resource "morpheus_instance_snapshot" "tf_example_instance_snapshot" {
+  instance_id    = morpheus_vsphere_instance.tf_example_vsphere_instance.id
+  name           = "pre-patching"
+  description    = "Snapshot taken before patching"
+  revert_trigger = "1"
+}
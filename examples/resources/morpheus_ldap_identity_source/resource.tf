@@ -0,0 +1,25 @@
+resource "morpheus_ldap_identity_source" "tf_example_ldap_identity_source" {
+  tenant_id               = 1
+  name                    = "ldapdemo"
+  description             = "TF example LDAP identity source"
+  url                     = "ldaps://ldap.contoso.com:636"
+  binding_username        = "cn=morpheus,ou=service,dc=contoso,dc=com"
+  binding_password        = "Password123"
+  user_fqn_expression     = "uid=$username,ou=users,dc=contoso,dc=com"
+  required_group_fqn      = "cn=morpheus,ou=groups,dc=contoso,dc=com"
+  username_attribute      = "uid"
+  common_name_attribute   = "cn"
+  first_name_attribute    = "givenName"
+  last_name_attribute     = "sn"
+  email_attribute         = "mail"
+  unique_member_attribute = "uniqueMember"
+  member_of_attribute     = "memberOf"
+  default_account_role_id = 7
+
+  role_mapping {
+    role_id    = 2
+    role_name  = "developers"
+    group_name = "developers"
+    group_fqn  = "cn=developers,ou=groups,dc=contoso,dc=com"
+  }
+}
@@ -0,0 +1,23 @@
+resource "morpheus_nutanix_prism_cloud" "tf_example_nutanix_prism_cloud" {
+  name                       = "tf-prism-demo"
+  code                       = "tf-prism-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  api_url                    = "https://prism.example.com:9440"
+  username                   = "admin"
+  password                   = "Password123"
+  ignore_ssl_errors          = false
+  import_existing_vms        = true
+  enable_hypervisor_console  = true
+  import_images              = true
+  import_networks            = true
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  datacenter_id              = "tfprismdemo"
+  guidance                   = "manual"
+  costing                    = "costing"
+  agent_install_mode         = "cloudInit"
+}
@@ -0,0 +1,21 @@
+data "morpheus_credential" "prism_credentials" {
+  name = "prismdemo"
+}
+
+resource "morpheus_nutanix_prism_cloud" "tf_example_nutanix_prism_cloud" {
+  name                       = "tf-prism-demo"
+  code                       = "tf-prism-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  api_url                    = "https://prism.example.com:9440"
+  credential_id              = data.morpheus_credential.prism_credentials.id
+  import_existing_vms        = false
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  guidance                   = "off"
+  costing                    = "off"
+  agent_install_mode         = "ssh"
+}
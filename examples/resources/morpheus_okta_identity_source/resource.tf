@@ -0,0 +1,16 @@
+resource "morpheus_okta_identity_source" "tf_example_okta_identity_source" {
+  tenant_id               = 1
+  name                    = "oktademo"
+  description             = "TF example Okta identity source"
+  okta_url                = "https://contoso.okta.com"
+  administrator_api_token = "00a1b2c3d4e5f6g7h8i9j0"
+  required_group          = "morpheus"
+  default_account_role_id = 7
+
+  role_mapping {
+    role_id    = 2
+    role_name  = "developers"
+    group_name = "developers"
+    group_fqn  = "developers"
+  }
+}
@@ -0,0 +1,26 @@
+resource "morpheus_openstack_cloud" "tf_example_openstack_cloud" {
+  name                       = "tf-openstack-demo"
+  code                       = "tf-openstack-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  identity_url               = "https://openstack.example.com:5000/v3"
+  domain_id                  = "default"
+  project_name               = "morpheus-demo"
+  region                     = "RegionOne"
+  os_release                 = "yoga"
+  username                   = "morpheus"
+  password                   = "Password123"
+  ignore_ssl_errors          = false
+  import_existing_vms        = true
+  import_images              = true
+  import_networks            = true
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  datacenter_id              = "tfopenstackdemo"
+  guidance                   = "manual"
+  costing                    = "costing"
+  agent_install_mode         = "cloudInit"
+}
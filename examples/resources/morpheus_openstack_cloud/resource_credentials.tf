@@ -0,0 +1,23 @@
+data "morpheus_credential" "openstack_credentials" {
+  name = "openstackdemo"
+}
+
+resource "morpheus_openstack_cloud" "tf_example_openstack_cloud" {
+  name                       = "tf-openstack-demo"
+  code                       = "tf-openstack-demo"
+  location                   = "colorado"
+  visibility                 = "public"
+  tenant_id                  = 1
+  enabled                    = true
+  automatically_power_on_vms = true
+  identity_url               = "https://openstack.example.com:5000/v3"
+  project_name               = "morpheus-demo"
+  region                     = "RegionOne"
+  credential_id              = data.morpheus_credential.openstack_credentials.id
+  import_existing_vms        = false
+  appliance_url              = "https://morpheus.local"
+  time_zone                  = "America/Denver"
+  guidance                   = "off"
+  costing                    = "off"
+  agent_install_mode         = "ssh"
+}
@@ -0,0 +1 @@
+terraform import morpheus_saml_identity_source.tf_example_saml_identity_source 1
\ No newline at end of file
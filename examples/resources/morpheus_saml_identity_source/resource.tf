@@ -0,0 +1,24 @@
+resource "morpheus_saml_identity_source" "tf_example_saml_identity_source" {
+  tenant_id                = 1
+  name                     = "samldemo"
+  description              = "TF example SAML identity source"
+  login_url                = "https://idp.contoso.com/saml/sso"
+  logout_url               = "https://idp.contoso.com/saml/logout"
+  signature_mode           = "CustomRSA"
+  request_x509_certificate = file("${path.module}/saml.crt")
+  request_private_key      = file("${path.module}/saml.key")
+  public_key               = file("${path.module}/idp.crt")
+  role_attribute_name      = "groups"
+  required_attribute_value = "morpheus"
+  given_name_attribute     = "firstName"
+  surname_attribute        = "lastName"
+  email_attribute          = "email"
+  default_account_role_id  = 7
+
+  role_mapping {
+    role_id    = 2
+    role_name  = "developers"
+    group_name = "developers"
+    group_fqn  = "developers"
+  }
+}
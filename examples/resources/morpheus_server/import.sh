@@ -0,0 +1 @@
+terraform import morpheus_server.tf_example_server 1
@@ -0,0 +1,16 @@
+resource "morpheus_server" "tf_example_server" {
+  name          = "tf-example-server"
+  description   = "Terraform server example"
+  cloud_id      = 1
+  ip_address    = "10.0.0.25"
+  ssh_username  = "root"
+  ssh_password  = var.ssh_password
+  plan_id       = 4
+  install_agent = true
+
+  labels = ["brownfield"]
+
+  tags = {
+    owner = "ops"
+  }
+}
@@ -0,0 +1 @@
+terraform import morpheus_tenant_role.tf_example_tenant_role 1
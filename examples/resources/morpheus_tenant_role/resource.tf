@@ -0,0 +1,21 @@
+resource "morpheus_tenant_role" "tf_example_tenant_role" {
+  name        = "tf_example_tenant_role"
+  description = "Terraform example tenant role"
+
+  feature_permission {
+    code   = "provisioning"
+    access = "full"
+  }
+
+  global_cloud_access = "custom"
+
+  cloud_access {
+    cloud_id = 1
+    access   = "full"
+  }
+
+  global_instance_type_access     = "full"
+  global_blueprint_access         = "full"
+  global_catalog_item_type_access = "none"
+  global_workflow_access          = "full"
+}
@@ -0,0 +1,2 @@
+terraform import morpheus_user.tf_example_user 1
+terraform import morpheus_user.tf_example_user 2:1
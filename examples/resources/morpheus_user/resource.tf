@@ -0,0 +1,13 @@
+resource "morpheus_user" "tf_example_user" {
+  tenant_id               = 2
+  username                = "svc-deploy"
+  email                   = "svc-deploy@example.com"
+  first_name              = "Deploy"
+  last_name               = "Service"
+  password                = var.user_password
+  password_reset_on_login = false
+  role_ids                = [3, 4]
+  receive_notifications   = false
+  linux_username          = "svcdeploy"
+  linux_key_pair_id       = 5
+}
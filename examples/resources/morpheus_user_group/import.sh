@@ -0,0 +1 @@
+terraform import morpheus_user_group.tf_example_user_group 1
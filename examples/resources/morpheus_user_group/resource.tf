@@ -0,0 +1,8 @@
+resource "morpheus_user_group" "tf_example_user_group" {
+  name         = "tf-example-user-group"
+  description  = "Terraform user group example"
+  server_group = "ops"
+  sudo_access  = true
+  shared_user  = false
+  user_ids     = [morpheus_user.tf_example_user.id]
+}
@@ -1 +1 @@
-terraform import morpheus_user_role.tf_example_user_role 1
\ No newline at end of file
+terraform import morpheus_user_role.tf_example_user_role 1
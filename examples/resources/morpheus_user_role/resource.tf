@@ -1,25 +1,40 @@
 resource "morpheus_user_role" "tf_example_user_role" {
   name               = "tf_example_user_role"
   description        = "Terraform example user role"
-  multitenant_role   = true
+  multitenant        = true
   multitenant_locked = true
-  permission {
-    global_groups_permission             = "full"
-    global_instance_types_permission     = "full"
-    global_blueprints_permission         = "full"
-    global_report_types_permission       = "full"
-    global_catalog_item_types_permission = "full"
-    global_vdi_pools_permission          = "full"
-    default_persona                      = "catalog"
+  default_persona    = "serviceCatalog"
 
-    feature_permission {
-      name   = "Admin: Appliance Settings"
-      access = "read"
-    }
+  feature_permission {
+    code   = "admin-appliance"
+    access = "read"
+  }
+
+  feature_permission {
+    code   = "provisioning"
+    access = "full"
+  }
+
+  global_group_access = "custom"
+
+  group_access {
+    group_id = 1
+    access   = "full"
+  }
+
+  group_access {
+    group_id = 2
+    access   = "read"
+  }
+
+  global_cloud_access             = "full"
+  global_instance_type_access     = "full"
+  global_blueprint_access         = "full"
+  global_catalog_item_type_access = "full"
+  global_workflow_access          = "custom"
 
-    feature_permission {
-      name   = "Admin: Appliance Settings"
-      access = "read"
-    }
+  workflow_access {
+    workflow_id = 4
+    access      = "full"
   }
-}
\ No newline at end of file
+}
@@ -43,6 +43,21 @@ resource "morpheus_vsphere_instance" "tf_example_vsphere_instance" {
     network_id = data.morpheus_network.vmnetwork.id
   }
 
+  volumes {
+    root         = true
+    name         = "root"
+    size         = 20
+    storage_type = 1
+    datastore_id = 5
+  }
+
+  volumes {
+    name         = "data"
+    size         = 50
+    storage_type = 1
+    datastore_id = 5
+  }
+
   tags = {
     name = "apachetf"
   }
@@ -1,6 +1,11 @@
 package morpheus
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -17,14 +22,63 @@ type Config struct {
 	// Scope            string // "scope"
 	// GrantType            string  // "bearer"
 
-	Insecure bool
+	Insecure   bool
+	CACertFile string
+	CACertPEM  string
+	ClientCert string // path or PEM content
+	ClientKey  string // path or PEM content
+	ProxyURL   string
+
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	TenantID   int64  // optional, scopes requests to a subtenant
+	TenantName string // optional, resolved to TenantID
+
+	client *morpheus.Client
+	relay  *apiRelay
+}
 
+// providerMeta is the meta of the provider passed to resources and data
+// sources.
+type providerMeta struct {
 	client *morpheus.Client
+	// tenantID is the id of the subtenant the provider is scoped to, 0 when
+	// it operates in the tenant of the authenticated user
+	tenantID int64
 }
 
 func (c *Config) Client() (*morpheus.Client, diag.Diagnostics) {
 	if c.client == nil {
-		client := morpheus.NewClient(c.Url)
+		target, err := url.Parse(c.Url)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, diag.Errorf("invalid url %q: must be an absolute URL such as https://morpheus.example.com", c.Url)
+		}
+
+		transport, err := c.transport()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		var roundTripper http.RoundTripper = transport
+		if c.MaxRetries > 0 {
+			roundTripper = &retryTransport{
+				transport:  transport,
+				maxRetries: c.MaxRetries,
+				waitMin:    c.RetryWaitMin,
+				waitMax:    c.RetryWaitMax,
+			}
+		}
+
+		// the SDK client talks to the appliance through the relay,
+		// see transport.go
+		relay, err := newAPIRelay(target, roundTripper)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("error configuring API client: %s", err))
+		}
+
+		client := morpheus.NewClient(relay.URL)
 		// should validate url here too, and maybe ping it
 		// logging with access token or username and password?
 		if c.Username != "" {
@@ -34,7 +88,17 @@ func (c *Config) Client() (*morpheus.Client, diag.Diagnostics) {
 			var expiresIn int64 = 86400 // lie (unused atm)
 			client.SetAccessToken(c.AccessToken, c.RefreshToken, expiresIn, "write")
 		}
+
+		if c.TenantName != "" {
+			tenantID, err := findTenantID(client, c.TenantName)
+			if err != nil {
+				relay.Close()
+				return nil, diag.FromErr(err)
+			}
+			c.TenantID = tenantID
+		}
 		c.client = client
+		c.relay = relay
 	}
 	return c.client, nil
 }
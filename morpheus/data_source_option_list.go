@@ -32,7 +32,7 @@ func dataSourceMorpheusOptionList() *schema.Resource {
 }
 
 func dataSourceMorpheusOptionListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -41,7 +41,7 @@ func dataSourceMorpheusPlan() *schema.Resource {
 }
 
 func dataSourceMorpheusPlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -46,7 +46,7 @@ func dataSourceMorpheusTenant() *schema.Resource {
 }
 
 func dataSourceMorpheusTenantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -2,7 +2,10 @@ package morpheus
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,3 +23,12 @@ func suppressEquivalentJsonDiffs(k, old, new string, d *schema.ResourceData) boo
 
 	return jsonBytesEqual(ob.Bytes(), nb.Bytes())
 }
+
+// suppressHashedSecretDiff suppresses the diff of a secret the API only
+// returns as a SHA-256 hash.
+func suppressHashedSecretDiff(k, old, new string, d *schema.ResourceData) bool {
+	h := sha256.New()
+	h.Write([]byte(new))
+	sha256_hash := hex.EncodeToString(h.Sum(nil))
+	return strings.EqualFold(old, sha256_hash)
+}
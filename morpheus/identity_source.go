@@ -0,0 +1,439 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// identitySourceType describes an identity source type: its code, the
+// prefix of the group attributes of its role mappings and how its
+// attributes map to the config of the identity source.
+type identitySourceType struct {
+	code        string
+	title       string
+	description string
+	groupPrefix string
+	groupName   string
+	groupFqn    string
+	settings    []identitySourceSetting
+}
+
+// identitySourceSetting maps an attribute to a field of the identity
+// source config. Secret fields are write only, the API returns their
+// sha256 hash in the <field>Hash field when it returns anything at all.
+// On/off fields are sent as "on" or "off" rather than a boolean.
+type identitySourceSetting struct {
+	attribute string
+	field     string
+	secret    bool
+	onOff     bool
+}
+
+// identitySourceSchema returns the attributes shared by every identity
+// source type along with the given attributes of the type.
+func identitySourceSchema(source identitySourceType, attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("The ID of the %s identity source", source.description),
+			Computed:    true,
+		},
+		"tenant_id": {
+			Type:        schema.TypeInt,
+			Description: "The ID of the Morpheus tenant to associate the identity source with",
+			Required:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("The name of the %s identity source", source.description),
+			Required:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("The description of the %s identity source", source.description),
+			Optional:    true,
+			Computed:    true,
+		},
+		"default_account_role_id": {
+			Type:        schema.TypeInt,
+			Description: "The id of the default role a user is assigned when they are in the required group or if no specific group mapping applies to the user",
+			Required:    true,
+		},
+		"enable_role_mapping_permission": {
+			Type:        schema.TypeBool,
+			Description: "When enabled, Tenant users with appropriate rights to view and edit Roles will have the ability to set role mapping for the Identity Source integration",
+			Optional:    true,
+			Computed:    true,
+		},
+		"role_mapping": {
+			Description: fmt.Sprintf("The %s to Morpheus Role mapping, one per %s", source.title, source.groupPrefix+"_fqn"),
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Set:         identitySourceRoleMappingHash(source.groupPrefix),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"role_id": {
+						Description: "The id of the Morpheus role to map to, looked up from role_name when not set",
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Computed:    true,
+					},
+					"role_name": {
+						Description: "The name or authority of the Morpheus role to map to",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+					},
+					source.groupPrefix + "_name": {
+						Description: source.groupName,
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+					},
+					source.groupPrefix + "_fqn": {
+						Description: source.groupFqn,
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+	for k, v := range attributes {
+		s[k] = v
+	}
+	return s
+}
+
+func createIdentitySource(ctx context.Context, d *schema.ResourceData, meta interface{}, source identitySourceType) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	identitySource, err := identitySourcePayload(client, d, source)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"userSource": identitySource,
+		},
+	}
+	resp, err := client.CreateIdentitySource(int64(d.Get("tenant_id").(int)), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateIdentitySourceResult)
+	if !result.Success {
+		return diag.Errorf("error creating identity source: %s", result.Message)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.IdentitySource.ID))
+	readIdentitySource(ctx, d, meta, source)
+	return diags
+}
+
+func readIdentitySource(ctx context.Context, d *schema.ResourceData, meta interface{}, source identitySourceType) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.GetIdentitySource(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetIdentitySourceResult)
+	identitySource := result.IdentitySource
+	if identitySource.Type != "" && identitySource.Type != source.code {
+		return diag.Errorf("identity source %s has the type %s, expected %s", id, identitySource.Type, source.code)
+	}
+	d.SetId(int64ToString(identitySource.ID))
+	if identitySource.Account.ID != 0 {
+		d.Set("tenant_id", identitySource.Account.ID)
+	}
+	d.Set("name", identitySource.Name)
+	d.Set("description", identitySource.Description)
+	d.Set("enable_role_mapping_permission", identitySource.AllowCustomMappings)
+	d.Set("default_account_role_id", identitySource.DefaultAccountRole.ID)
+
+	// The sdk config struct does not cover every type, read it generically
+	var payload struct {
+		UserSource struct {
+			Config map[string]interface{} `json:"config"`
+		} `json:"userSource"`
+	}
+	if err := json.Unmarshal(resp.Body, &payload); err != nil {
+		return diag.FromErr(err)
+	}
+	config := payload.UserSource.Config
+	for _, setting := range source.settings {
+		if setting.secret {
+			if hash, ok := config[setting.field+"Hash"].(string); ok && hash != "" {
+				d.Set(setting.attribute, hash)
+			}
+			continue
+		}
+		value, ok := config[setting.field]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if setting.onOff {
+				d.Set(setting.attribute, v != "off")
+			} else {
+				d.Set(setting.attribute, v)
+			}
+		default:
+			d.Set(setting.attribute, v)
+		}
+	}
+
+	d.Set("role_mapping", flattenIdentitySourceRoleMappings(d, identitySource, source.groupPrefix))
+	return diags
+}
+
+func updateIdentitySource(ctx context.Context, d *schema.ResourceData, meta interface{}, source identitySourceType) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	identitySource, err := identitySourcePayload(client, d, source)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"userSource": identitySource,
+		},
+	}
+	resp, err := client.UpdateIdentitySource(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateIdentitySourceResult)
+	if !result.Success {
+		return diag.Errorf("error updating identity source: %s", result.Message)
+	}
+
+	return readIdentitySource(ctx, d, meta, source)
+}
+
+func resourceIdentitySourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteIdentitySource(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// identitySourcePayload returns the identity source sent on create and
+// update. Secrets are only sent when they are set on create or changed, a
+// secret cleared on update is sent empty so the API removes it.
+func identitySourcePayload(client *morpheus.Client, d *schema.ResourceData, source identitySourceType) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	config["allowCustomMappings"] = d.Get("enable_role_mapping_permission").(bool)
+	for _, setting := range source.settings {
+		value := d.Get(setting.attribute)
+		if setting.secret && (d.Id() == "" && value == "" || d.Id() != "" && !d.HasChange(setting.attribute)) {
+			continue
+		}
+		if setting.onOff {
+			value = "off"
+			if d.Get(setting.attribute).(bool) {
+				value = "on"
+			}
+		}
+		config[setting.field] = value
+	}
+
+	roleMappings, err := expandIdentitySourceRoleMappings(client, d, source.groupPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"type":        source.code,
+		"config":      config,
+		"defaultAccountRole": map[string]interface{}{
+			"id": d.Get("default_account_role_id").(int),
+		},
+		"roleMappings": roleMappings,
+	}, nil
+}
+
+// identitySourceRoleMappingHash identifies role mappings by their group and
+// the role they are configured with, so reordered mappings plan clean and
+// a group mapped to another role plans a change of its mapping. The role_id
+// and role_name a mapping is not configured with are left empty in the
+// state, see flattenIdentitySourceRoleMappings.
+func identitySourceRoleMappingHash(groupPrefix string) schema.SchemaSetFunc {
+	return func(v interface{}) int {
+		mapping := v.(map[string]interface{})
+		roleID, _ := mapping["role_id"].(int)
+		roleName, _ := mapping["role_name"].(string)
+		return schema.HashString(fmt.Sprintf("%s-%d-%s", mapping[groupPrefix+"_fqn"], roleID, roleName))
+	}
+}
+
+// customizeIdentitySourceRoleMappingDiff rejects groups with more than one
+// role mapping. The set keeps a single mapping per group, so the
+// configuration itself is checked.
+func customizeIdentitySourceRoleMappingDiff(groupPrefix string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+		mappings := config.GetAttr("role_mapping")
+		if mappings.IsNull() || !mappings.IsKnown() {
+			return nil
+		}
+		configured := make(map[string]bool)
+		for it := mappings.ElementIterator(); it.Next(); {
+			_, mapping := it.Element()
+			fqn := mapping.GetAttr(groupPrefix + "_fqn")
+			if fqn.IsNull() || !fqn.IsKnown() {
+				continue
+			}
+			if configured[fqn.AsString()] {
+				return fmt.Errorf("the group %s has more than one role mapping", fqn.AsString())
+			}
+			configured[fqn.AsString()] = true
+		}
+		return nil
+	}
+}
+
+// identitySourceRoleID returns the ID of the role of a role mapping. A
+// mapping configured with both a role_id and a role_name whose role_name
+// changed still holds the role_id of its previous role, so the role is
+// looked up by name unless role_id changed as well.
+func identitySourceRoleID(client *morpheus.Client, fqn string, mapping map[string]interface{}, previous map[string]interface{}) (int64, error) {
+	roleID := int64(mapping["role_id"].(int))
+	roleName := mapping["role_name"].(string)
+	if previous != nil && roleName != previous["role_name"].(string) && mapping["role_id"].(int) == previous["role_id"].(int) {
+		roleID = 0
+	}
+	if roleID == 0 && roleName != "" {
+		resp, err := client.FindRoleByName(roleName)
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return 0, fmt.Errorf("error looking up the role %s of the role mapping of %s: %s", roleName, fqn, err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+		roleID = resp.Result.(*morpheus.GetRoleResult).Role.ID
+	}
+	if roleID == 0 {
+		return 0, fmt.Errorf("the role mapping of %s requires a role_id or a role_name", fqn)
+	}
+	return roleID, nil
+}
+
+// expandIdentitySourceRoleMappings maps the role_mapping attribute to the
+// role mappings of an identity source, looking up the role of mappings
+// configured with a role_name only or whose role_name changed.
+func expandIdentitySourceRoleMappings(client *morpheus.Client, d *schema.ResourceData, groupPrefix string) ([]map[string]interface{}, error) {
+	fqnKey := groupPrefix + "_fqn"
+	old, new := d.GetChange("role_mapping")
+	previous := make(map[string]map[string]interface{})
+	for _, mapping := range old.(*schema.Set).List() {
+		mappingState := mapping.(map[string]interface{})
+		previous[mappingState[fqnKey].(string)] = mappingState
+	}
+	roleMappings := make([]map[string]interface{}, 0)
+	for _, mapping := range new.(*schema.Set).List() {
+		mappingConfig := mapping.(map[string]interface{})
+		fqn := mappingConfig[fqnKey].(string)
+		roleID, err := identitySourceRoleID(client, fqn, mappingConfig, previous[fqn])
+		if err != nil {
+			return nil, err
+		}
+		roleMappings = append(roleMappings, map[string]interface{}{
+			"sourceRoleName": mappingConfig[groupPrefix+"_name"].(string),
+			"sourceRoleFqn":  fqn,
+			"mappedRole": map[string]interface{}{
+				"id": roleID,
+			},
+		})
+	}
+	return roleMappings, nil
+}
+
+// flattenIdentitySourceRoleMappings maps the role mappings of an identity
+// source to the role_mapping attribute. The role id, role name and group
+// name are left empty when the current mapping of the group leaves them
+// empty, so they do not change the identity of mappings configured
+// without them.
+func flattenIdentitySourceRoleMappings(d *schema.ResourceData, identitySource *morpheus.IdentitySource, groupPrefix string) []map[string]interface{} {
+	fqnKey := groupPrefix + "_fqn"
+	nameKey := groupPrefix + "_name"
+	current := make(map[string]map[string]interface{})
+	for _, mapping := range d.Get("role_mapping").(*schema.Set).List() {
+		mappingConfig := mapping.(map[string]interface{})
+		current[mappingConfig[fqnKey].(string)] = mappingConfig
+	}
+
+	var roleMappings []map[string]interface{}
+	for _, roleMapping := range identitySource.RoleMappings {
+		roleOutput := make(map[string]interface{})
+		roleOutput[fqnKey] = roleMapping.SourceRoleFqn
+		roleOutput[nameKey] = roleMapping.SourceRoleName
+		roleOutput["role_id"] = roleMapping.MappedRole.ID
+		roleOutput["role_name"] = roleMapping.MappedRole.Authority
+		if mapping, ok := current[roleMapping.SourceRoleFqn]; ok {
+			if mapping[nameKey].(string) == "" {
+				roleOutput[nameKey] = ""
+			}
+			if mapping["role_id"].(int) == 0 {
+				roleOutput["role_id"] = 0
+			}
+			if mapping["role_name"].(string) == "" {
+				roleOutput["role_name"] = ""
+			}
+		}
+		roleMappings = append(roleMappings, roleOutput)
+	}
+	return roleMappings
+}
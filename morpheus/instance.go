@@ -0,0 +1,861 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provisioning a new instance takes a while, these control how long to
+// wait before and between checking on it.
+var (
+	instanceProvisionDelay        = 3 * time.Minute
+	instanceProvisionPollInterval = 1 * time.Minute
+)
+
+// instanceFailureStates are the statuses a provisioning instance does not
+// recover from.
+var instanceFailureStates = []string{"failed", "warning", "denied", "cancelled"}
+
+// waitForInstanceProvision waits for a new instance to finish provisioning,
+// failing as soon as it reaches one of the instanceFailureStates.
+func waitForInstanceProvision(ctx context.Context, client *morpheus.Client, id int64, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "provisioning", "starting", "stopping"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(id, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			instance := result.Instance
+			for _, status := range instanceFailureStates {
+				if instance.Status == status {
+					return result, instance.Status, instanceProvisionError(client, id, instance.Status, instanceDetails.Body)
+				}
+			}
+			return result, instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionDelay,
+		PollInterval: instanceProvisionPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// instanceProvisionError describes why an instance failed to provision from
+// its status message and the steps of its provisioning history.
+func instanceProvisionError(client *morpheus.Client, id int64, status string, body []byte) error {
+	var details struct {
+		Instance struct {
+			StatusMessage string `json:"statusMessage"`
+			ErrorMessage  string `json:"errorMessage"`
+		} `json:"instance"`
+	}
+	json.Unmarshal(body, &details)
+	message := fmt.Sprintf("instance %d is %s", id, status)
+	if details.Instance.StatusMessage != "" {
+		message += ": " + details.Instance.StatusMessage
+	} else if details.Instance.ErrorMessage != "" {
+		message += ": " + details.Instance.ErrorMessage
+	}
+
+	processes, err := instanceHistory(client, id)
+	if err != nil {
+		return fmt.Errorf("%s", message)
+	}
+
+	var steps []string
+	for _, process := range processes {
+		steps = append(steps, process.String())
+		for _, event := range process.Events {
+			steps = append(steps, "  "+event.String())
+		}
+	}
+	if len(steps) > 0 {
+		message += "\n\nprovisioning history:\n  " + strings.Join(steps, "\n  ")
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// instanceProcess is a step of the instance history, such as provisioning
+// and the events it went through.
+type instanceProcess struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"displayName"`
+	ProcessType struct {
+		Name string `json:"name"`
+	} `json:"processType"`
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	Events []instanceProcess `json:"events"`
+}
+
+func (p instanceProcess) String() string {
+	name := p.DisplayName
+	if name == "" {
+		name = p.ProcessType.Name
+	}
+	step := fmt.Sprintf("%s: %s", name, p.Status)
+	if p.Error != "" {
+		step += " - " + p.Error
+	}
+	return step
+}
+
+// instanceHistory returns the processes the instance went through.
+func instanceHistory(client *morpheus.Client, id int64) ([]instanceProcess, error) {
+	var history struct {
+		Processes []instanceProcess `json:"processes"`
+	}
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%d/history", morpheus.InstancesPath, id),
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	if err := json.Unmarshal(resp.Body, &history); err != nil {
+		return nil, err
+	}
+	return history.Processes, nil
+}
+
+// lastInstanceProcessID returns the id of the latest process of the
+// instance, 0 when it has none.
+func lastInstanceProcessID(client *morpheus.Client, id int64) (int64, error) {
+	processes, err := instanceHistory(client, id)
+	if err != nil {
+		return 0, err
+	}
+	var last int64
+	for _, process := range processes {
+		if process.ID > last {
+			last = process.ID
+		}
+	}
+	return last, nil
+}
+
+// waitForInstanceProcess waits for the first process of the instance
+// started after the process with the given id to complete, failing when
+// the process fails.
+func waitForInstanceProcess(ctx context.Context, client *morpheus.Client, id int64, after int64, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "queued", "running"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			processes, err := instanceHistory(client, id)
+			if err != nil {
+				return "", "", err
+			}
+			var next *instanceProcess
+			for i, process := range processes {
+				if process.ID > after && (next == nil || process.ID < next.ID) {
+					next = &processes[i]
+				}
+			}
+			// the process may not have been started yet
+			if next == nil {
+				return processes, "pending", nil
+			}
+			if next.Status == "failed" {
+				return next, next.Status, fmt.Errorf("%s", next)
+			}
+			return next, next.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// deleteFailedInstance removes an instance that failed to provision.
+func deleteFailedInstance(client *morpheus.Client, id int64) error {
+	req := &morpheus.Request{
+		QueryParams: map[string]string{},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.DeleteInstance(id, req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil
+		}
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	return nil
+}
+
+// instanceRuntime holds the attributes of a provisioned instance that are
+// missing from the SDK Instance type.
+type instanceRuntime struct {
+	Status         string  `json:"status"`
+	HostName       string  `json:"hostName"`
+	ExternalID     string  `json:"externalId"`
+	DateCreated    string  `json:"dateCreated"`
+	Servers        []int64 `json:"servers"`
+	ConnectionInfo []struct {
+		IP string `json:"ip"`
+	} `json:"connectionInfo"`
+}
+
+// setInstanceRuntimeAttributes sets the status, addresses and servers of
+// the instance in the response. The external ID and the addresses of the
+// network interfaces come from the first server of the instance, the
+// addresses are returned in the order of its network interfaces. A server
+// that can not be found is reported as a warning.
+func setInstanceRuntimeAttributes(client *morpheus.Client, d *schema.ResourceData, resp *morpheus.Response) ([]string, diag.Diagnostics) {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	var result struct {
+		Instance instanceRuntime `json:"instance"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	instance := result.Instance
+
+	var ipAddresses []string
+	addIPAddress := func(ip string) {
+		if ip == "" {
+			return
+		}
+		for _, existing := range ipAddresses {
+			if existing == ip {
+				return
+			}
+		}
+		ipAddresses = append(ipAddresses, ip)
+	}
+	for _, connection := range instance.ConnectionInfo {
+		addIPAddress(connection.IP)
+	}
+
+	externalID := instance.ExternalID
+	var assignedIPs []string
+	if len(instance.Servers) > 0 {
+		serverResp, err := client.Execute(&morpheus.Request{
+			Method: "GET",
+			Path:   fmt.Sprintf("%s/%d", serversPath, instance.Servers[0]),
+		})
+		if err != nil && serverResp != nil && serverResp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", serverResp, err)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("server %d of instance %s not found", instance.Servers[0], d.Id()),
+				Detail:   "The external ID and the addresses of the network interfaces of the instance could not be read.",
+			})
+		} else if err != nil {
+			log.Printf("API FAILURE: %s - %s", serverResp, err)
+			return nil, diag.FromErr(err)
+		} else {
+			log.Printf("API RESPONSE: %s", serverResp)
+			var serverResult struct {
+				Server struct {
+					ExternalID string                   `json:"externalId"`
+					InternalIP string                   `json:"internalIp"`
+					ExternalIP string                   `json:"externalIp"`
+					Interfaces []map[string]interface{} `json:"interfaces"`
+				} `json:"server"`
+			}
+			if err := json.Unmarshal(serverResp.Body, &serverResult); err != nil {
+				return nil, diag.FromErr(err)
+			}
+			server := serverResult.Server
+			if server.ExternalID != "" {
+				externalID = server.ExternalID
+			}
+			addIPAddress(server.InternalIP)
+			addIPAddress(server.ExternalIP)
+			for _, serverInterface := range server.Interfaces {
+				ip, _ := serverInterface["ipAddress"].(string)
+				addIPAddress(ip)
+				assignedIPs = append(assignedIPs, ip)
+			}
+		}
+	}
+
+	primaryIPAddress := ""
+	if len(ipAddresses) > 0 {
+		primaryIPAddress = ipAddresses[0]
+	}
+	d.Set("status", instance.Status)
+	d.Set("hostname", instance.HostName)
+	d.Set("primary_ip_address", primaryIPAddress)
+	d.Set("ip_addresses", ipAddresses)
+	d.Set("server_ids", instance.Servers)
+	d.Set("external_id", externalID)
+	d.Set("date_created", instance.DateCreated)
+	return assignedIPs, diags
+}
+
+// instanceTags converts the tags map into the list of name/value pairs
+// expected by the API.
+func instanceTags(tagsInput map[string]interface{}) []map[string]interface{} {
+	var tags []map[string]interface{}
+	for key, value := range tagsInput {
+		tag := make(map[string]interface{})
+		tag["name"] = key
+		tag["value"] = value.(string)
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// suppressMaskedEnvironmentVariableDiff ignores the value of a masked
+// environment variable when the state only holds the value masked by the
+// API, which is the case for imported instances. Values applied by
+// terraform are kept in the state and compared as usual.
+func suppressMaskedEnvironmentVariableDiff(k, old, new string, d *schema.ResourceData) bool {
+	masked := d.Get(strings.TrimSuffix(k, "value") + "masked").(bool)
+	return masked && isMaskedValue(old)
+}
+
+// isMaskedValue reports whether value is a secret masked by the API.
+func isMaskedValue(value string) bool {
+	return value != "" && strings.Trim(value, "*") == ""
+}
+
+func parseNetworkInterfaces(interfaces []interface{}) []map[string]interface{} {
+	var networkInterfaces []map[string]interface{}
+	for i := 0; i < len(interfaces); i++ {
+		row := make(map[string]interface{})
+		item := (interfaces)[i].(map[string]interface{})
+		if item["network_id"] != nil {
+			row["network"] = map[string]interface{}{
+				"id": fmt.Sprintf("network-%d", item["network_id"].(int)),
+			}
+		}
+		if item["ip_address"] != nil {
+			row["ipAddress"] = item["ip_address"] //.(string)
+		}
+		if item["ip_mode"] != nil {
+			row["ipMode"] = item["ip_mode"] // .(string)
+		}
+		if item["network_interface_type_id"] != nil {
+			row["networkInterfaceTypeId"] = item["network_interface_type_id"] //.(int)
+		}
+		networkInterfaces = append(networkInterfaces, row)
+	}
+	return networkInterfaces
+}
+
+func parseStorageVolumes(volumes []interface{}) []map[string]interface{} {
+	var storageVolumes []map[string]interface{}
+	for i := 0; i < len(volumes); i++ {
+		row := make(map[string]interface{})
+		item := (volumes)[i].(map[string]interface{})
+		if item["root"] != nil {
+			row["rootVolume"] = item["root"]
+		}
+		if item["name"] != nil {
+			row["name"] = item["name"] // .(string)
+		}
+		if item["size"] != nil {
+			row["size"] = item["size"] // .(int)
+		}
+		if item["size_id"] != nil {
+			row["sizeId"] = item["size_id"] // .(int)
+		}
+		if item["storage_type"] != nil {
+			row["storageType"] = item["storage_type"] // .(int)
+		}
+		if item["datastore_id"] != nil {
+			row["datastoreId"] = item["datastore_id"] // .(int)
+		}
+		storageVolumes = append(storageVolumes, row)
+	}
+	return storageVolumes // .([]map[string]interface{})
+}
+
+func parseEnvironmentVariables(variables []interface{}) []map[string]interface{} {
+	var evars []map[string]interface{}
+	// iterate over the array of evars
+	for i := 0; i < len(variables); i++ {
+		row := make(map[string]interface{})
+		evarconfig := variables[i].(map[string]interface{})
+		for k, v := range evarconfig {
+			switch k {
+			case "name":
+				row["name"] = v.(string)
+			case "value":
+				row["value"] = v.(string)
+			case "export":
+				row["export"] = v.(bool)
+			case "masked":
+				row["masked"] = v
+			}
+		}
+		evars = append(evars, row)
+		//log.Printf("evars payload: %s", evars)
+	}
+	return evars
+}
+
+// resizeInstance reconfigures the instance with the configured plan,
+// cores, memory, volumes and network interfaces and waits for the change
+// to complete. Volumes and interfaces already attached to the instance are
+// matched by name and network so they are kept, anything else is added and
+// whatever is no longer configured is removed. Unchanged volumes and
+// interfaces are sent as they are.
+func resizeInstance(ctx context.Context, client *morpheus.Client, d *schema.ResourceData, timeout time.Duration) error {
+	id := toInt64(d.Id())
+	resp, err := client.GetInstance(id, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	instance := resp.Result.(*morpheus.GetInstanceResult).Instance
+
+	payload := map[string]interface{}{
+		"instance": map[string]interface{}{
+			"id": id,
+			"plan": map[string]interface{}{
+				"id": d.Get("plan_id").(int),
+			},
+		},
+		"volumes": instance.Volumes,
+	}
+	if d.HasChange("volumes") {
+		payload["volumes"] = resizeStorageVolumes(d.Get("volumes").([]interface{}), instance.Volumes)
+	}
+	if d.HasChange("interfaces") {
+		payload["networkInterfaces"] = resizeNetworkInterfaces(d.Get("interfaces").([]interface{}), instance.Interfaces)
+	}
+
+	// Custom cores and memory
+	servicePlanOptions, err := instanceServicePlanOptions(client, d)
+	if err != nil {
+		return err
+	}
+	if len(servicePlanOptions) > 0 {
+		payload["servicePlanOptions"] = servicePlanOptions
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%d/resize", morpheus.InstancesPath, id),
+		Body:   payload,
+		Result: &morpheus.UpdateInstanceResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	return waitForInstanceResize(ctx, client, id, timeout)
+}
+
+// instanceServicePlanOptions returns the custom cores and memory of the
+// instance, after checking the service plan allows customizing them.
+func instanceServicePlanOptions(client *morpheus.Client, d *schema.ResourceData) (map[string]interface{}, error) {
+	servicePlanOptions := make(map[string]interface{})
+	cores := d.Get("cores").(int)
+	memory := d.Get("memory").(int)
+	if cores == 0 && memory == 0 {
+		return servicePlanOptions, nil
+	}
+
+	resp, err := client.GetPlan(int64(d.Get("plan_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	var plan MorpheusPlan
+	if err := json.Unmarshal(resp.Body, &plan); err != nil {
+		return nil, err
+	}
+	if cores != 0 {
+		if !plan.ServicePlan.Customcores {
+			return nil, fmt.Errorf("service plan %s does not allow custom cores", plan.ServicePlan.Name)
+		}
+		servicePlanOptions["maxCores"] = cores
+	}
+	if memory != 0 {
+		if !plan.ServicePlan.Custommaxmemory {
+			return nil, fmt.Errorf("service plan %s does not allow custom memory", plan.ServicePlan.Name)
+		}
+		servicePlanOptions["maxMemory"] = memory
+	}
+	return servicePlanOptions, nil
+}
+
+// setInstanceServicePlanOptions reads the cores and memory of the instance
+// when they are customized. The sdk instance struct does not include them.
+func setInstanceServicePlanOptions(d *schema.ResourceData, resp *morpheus.Response) error {
+	var payload struct {
+		Instance struct {
+			MaxCores  int64 `json:"maxCores"`
+			MaxMemory int64 `json:"maxMemory"`
+		} `json:"instance"`
+	}
+	if err := json.Unmarshal(resp.Body, &payload); err != nil {
+		return err
+	}
+	if d.Get("cores").(int) != 0 {
+		d.Set("cores", payload.Instance.MaxCores)
+	}
+	if d.Get("memory").(int) != 0 {
+		d.Set("memory", payload.Instance.MaxMemory)
+	}
+	return nil
+}
+
+// instanceResizeAttributes are the attributes changed by resizing the
+// instance, which may reboot it.
+var instanceResizeAttributes = []string{"plan_id", "cores", "memory", "volumes", "interfaces"}
+
+// customizeInstanceResizeDiff refuses to plan a resize of an existing
+// instance unless allow_reboot_on_resize is set.
+func customizeInstanceResizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("allow_reboot_on_resize").(bool) {
+		return nil
+	}
+	for _, key := range instanceResizeAttributes {
+		if d.HasChange(key) {
+			return fmt.Errorf("changing the %s of instance %s may reboot it, set allow_reboot_on_resize to apply the change", key, d.Id())
+		}
+	}
+	return nil
+}
+
+// waitForInstanceResize waits for a reconfigured instance to settle,
+// failing when the resize leaves it in a warning state.
+func waitForInstanceResize(ctx context.Context, client *morpheus.Client, id int64, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"resizing", "stopping", "starting"},
+		Target:  []string{"running", "stopped"},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(id, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			instance := result.Instance
+			if instance.Status == "warning" {
+				return result, instance.Status, fmt.Errorf("instance %d is %s after the resize", id, instance.Status)
+			}
+			return result, instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// instancePowerActions maps each power state to the instance action that
+// puts the instance in it.
+var instancePowerActions = map[string]string{
+	"running":   "start",
+	"stopped":   "stop",
+	"suspended": "suspend",
+}
+
+// instancePowerTransitions are the statuses of an instance changing its
+// power state.
+var instancePowerTransitions = []string{"starting", "stopping", "suspending", "resuming"}
+
+// setInstancePowerState starts, stops, suspends or resumes the instance and
+// waits for it to reach the power state. A suspended instance is resumed
+// rather than started.
+func setInstancePowerState(ctx context.Context, client *morpheus.Client, id int64, powerState string, timeout time.Duration) error {
+	action, ok := instancePowerActions[powerState]
+	if !ok {
+		return fmt.Errorf("unsupported power state %q", powerState)
+	}
+	resp, err := client.GetInstance(id, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	status := resp.Result.(*morpheus.GetInstanceResult).Instance.Status
+	if status == powerState {
+		return nil
+	}
+	if status == "suspended" && powerState == "running" {
+		action = "resume"
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%d/%s", morpheus.InstancesPath, id, action),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// the instance may not have left its current status yet, any status
+	// other than that and the transitions fails the wait right away
+	stateConf := &resource.StateChangeConf{
+		Pending: append([]string{status}, instancePowerTransitions...),
+		Target:  []string{powerState},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(id, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			instance := result.Instance
+			return result, instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// setNewInstancePowerState powers a newly provisioned instance off when it
+// is not meant to be running. A failure is only a warning: the instance was
+// provisioned fine and must not be tainted, the power state read back
+// afterwards shows up as drift in the next plan.
+func setNewInstancePowerState(ctx context.Context, client *morpheus.Client, d *schema.ResourceData, id int64) diag.Diagnostics {
+	powerState := d.Get("power_state").(string)
+	if powerState == "" || powerState == "running" {
+		return nil
+	}
+	if err := setInstancePowerState(ctx, client, id, powerState, d.Timeout(schema.TimeoutCreate)); err != nil {
+		log.Printf("[WARN] Unable to change the power state of instance %d to %s: %s", id, powerState, err)
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("instance %d was created but could not be changed to %s", id, powerState),
+			Detail:   fmt.Sprintf("%s. The next plan changes the power state again.", err),
+		}}
+	}
+	return nil
+}
+
+// resizeStorageVolumes builds the volumes of a resize request. Configured
+// volumes are matched to the current volumes by name, or by position when
+// they have no name, new volumes are sent with an id of -1.
+func resizeStorageVolumes(volumes []interface{}, current *[]map[string]interface{}) []map[string]interface{} {
+	var existing []map[string]interface{}
+	if current != nil {
+		existing = *current
+	}
+	used := make(map[int]bool)
+	storageVolumes := parseStorageVolumes(volumes)
+	for i, row := range storageVolumes {
+		match := -1
+		for j, volume := range existing {
+			if used[j] {
+				continue
+			}
+			if name, _ := row["name"].(string); name != "" {
+				if volume["name"] == name {
+					match = j
+					break
+				}
+			} else if i == j {
+				match = j
+				break
+			}
+		}
+		if match == -1 {
+			row["id"] = -1
+			continue
+		}
+		used[match] = true
+		row["id"] = existing[match]["id"]
+	}
+	// the root volume can not be removed
+	for j, volume := range existing {
+		if !used[j] && volume["rootVolume"] == true {
+			root := map[string]interface{}{
+				"id":         volume["id"],
+				"rootVolume": true,
+				"name":       volume["name"],
+				"size":       volume["size"],
+			}
+			storageVolumes = append([]map[string]interface{}{root}, storageVolumes...)
+		}
+	}
+	return storageVolumes
+}
+
+// resizeNetworkInterfaces builds the network interfaces of a resize
+// request. Configured interfaces are matched to the current interfaces by
+// network, new interfaces are sent without an id.
+func resizeNetworkInterfaces(interfaces []interface{}, current *[]map[string]interface{}) []map[string]interface{} {
+	var existing []map[string]interface{}
+	if current != nil {
+		existing = *current
+	}
+	used := make(map[int]bool)
+	networkInterfaces := parseNetworkInterfaces(interfaces)
+	for i, row := range networkInterfaces {
+		networkID := interfaces[i].(map[string]interface{})["network_id"]
+		for j, networkInterface := range existing {
+			if used[j] {
+				continue
+			}
+			network, _ := networkInterface["network"].(map[string]interface{})
+			if networkID != nil && instanceResourceID(network["id"]) == networkID.(int) {
+				used[j] = true
+				row["id"] = networkInterface["id"]
+				break
+			}
+		}
+	}
+	return networkInterfaces
+}
+
+// flattenInstanceVolumes maps the volumes of an instance to the volumes
+// attribute.
+func flattenInstanceVolumes(volumes *[]map[string]interface{}) []map[string]interface{} {
+	var storageVolumes []map[string]interface{}
+	if volumes == nil {
+		return storageVolumes
+	}
+	for _, volume := range *volumes {
+		row := make(map[string]interface{})
+		row["root"] = volume["rootVolume"] == true
+		row["name"] = volume["name"]
+		row["size"] = instanceResourceID(volume["size"])
+		row["storage_type"] = instanceResourceID(volume["storageType"])
+		row["datastore_id"] = instanceResourceID(volume["datastoreId"])
+		storageVolumes = append(storageVolumes, row)
+	}
+	return storageVolumes
+}
+
+// flattenInstanceEnvironmentVariables maps the environment variables of an
+// instance to the evar attribute, leaving out the fields it does not have.
+// Masked values keep the value applied in the state since the API does not
+// return them.
+func flattenInstanceEnvironmentVariables(evars *[]map[string]interface{}, applied []interface{}) []map[string]interface{} {
+	var environmentVariables []map[string]interface{}
+	if evars == nil {
+		return environmentVariables
+	}
+	appliedValues := make(map[string]string)
+	for _, item := range applied {
+		if evar, ok := item.(map[string]interface{}); ok {
+			appliedValues[evar["name"].(string)] = evar["value"].(string)
+		}
+	}
+	for _, evar := range *evars {
+		row := make(map[string]interface{})
+		row["name"] = evar["name"]
+		row["value"] = ""
+		if evar["value"] != nil {
+			row["value"] = fmt.Sprint(evar["value"])
+		}
+		if value, ok := appliedValues[fmt.Sprint(evar["name"])]; ok && evar["masked"] == true && isMaskedValue(row["value"].(string)) {
+			row["value"] = value
+		}
+		row["export"] = evar["export"] == true
+		row["masked"] = evar["masked"] == true
+		environmentVariables = append(environmentVariables, row)
+	}
+	return environmentVariables
+}
+
+// flattenInstanceCustomOptions maps the custom options of an instance to
+// the custom_options attribute. Options are returned with their own types,
+// anything that is not a string is stored as JSON.
+func flattenInstanceCustomOptions(value interface{}) map[string]interface{} {
+	options, _ := value.(map[string]interface{})
+	customOptions := make(map[string]interface{})
+	for key, option := range options {
+		switch v := option.(type) {
+		case string:
+			customOptions[key] = v
+		case nil:
+			customOptions[key] = ""
+		default:
+			out, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			customOptions[key] = string(out)
+		}
+	}
+	return customOptions
+}
+
+// flattenInstanceInterfaces maps the network interfaces of an instance to
+// the interfaces attribute, along with the address assigned to each of
+// them. The addresses are matched by position, see
+// setInstanceRuntimeAttributes.
+func flattenInstanceInterfaces(interfaces *[]map[string]interface{}, assignedIPs []string) []map[string]interface{} {
+	var networkInterfaces []map[string]interface{}
+	if interfaces == nil {
+		return networkInterfaces
+	}
+	for i, networkInterface := range *interfaces {
+		row := make(map[string]interface{})
+		network, _ := networkInterface["network"].(map[string]interface{})
+		row["network_id"] = instanceResourceID(network["id"])
+		row["ip_address"] = networkInterface["ipAddress"]
+		row["ip_mode"] = networkInterface["ipMode"]
+		row["network_interface_type_id"] = instanceResourceID(networkInterface["networkInterfaceTypeId"])
+		row["assigned_ip"] = ""
+		if i < len(assignedIPs) {
+			row["assigned_ip"] = assignedIPs[i]
+		}
+		networkInterfaces = append(networkInterfaces, row)
+	}
+	return networkInterfaces
+}
+
+// instanceResourceID returns the numeric id of a value returned by the
+// instance API. Ids are returned as numbers, numeric strings, prefixed
+// strings such as network-12 or objects with an id, anything else is 0.
+func instanceResourceID(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case string:
+		id, err := strconv.Atoi(v[strings.LastIndex(v, "-")+1:])
+		if err != nil {
+			return 0
+		}
+		return id
+	case map[string]interface{}:
+		return instanceResourceID(v["id"])
+	}
+	return 0
+}
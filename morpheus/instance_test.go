@@ -0,0 +1,41 @@
+package morpheus
+
+import (
+	"testing"
+)
+
+func TestResizeStorageVolumes(t *testing.T) {
+	current := &[]map[string]interface{}{
+		{"id": float64(1), "name": "root", "rootVolume": true, "size": float64(20)},
+		{"id": float64(2), "name": "data", "size": float64(50)},
+	}
+	volumes := resizeStorageVolumes([]interface{}{
+		map[string]interface{}{"name": "data", "size": 60},
+		map[string]interface{}{"name": "", "size": 10},
+	}, current)
+	if len(volumes) != 3 {
+		t.Fatalf("expected the root volume to be kept, got %v", volumes)
+	}
+	if volumes[0]["id"] != float64(1) || volumes[1]["id"] != float64(2) || volumes[2]["id"] != -1 {
+		t.Fatalf("unexpected volume ids: %v", volumes)
+	}
+}
+
+func TestInstanceResourceID(t *testing.T) {
+	cases := map[string]struct {
+		value    interface{}
+		expected int
+	}{
+		"number":   {float64(12), 12},
+		"string":   {"12", 12},
+		"prefixed": {"network-12", 12},
+		"object":   {map[string]interface{}{"id": float64(12)}, 12},
+		"auto":     {"auto", 0},
+		"nil":      {nil, 0},
+	}
+	for name, c := range cases {
+		if id := instanceResourceID(c.value); id != c.expected {
+			t.Errorf("%s: expected %d, got %d", name, c.expected, id)
+		}
+	}
+}
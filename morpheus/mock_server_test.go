@@ -0,0 +1,497 @@
+package morpheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mockServer is an in-memory fake of the Morpheus API used to exercise
+// resources without a real appliance. Each registered collection behaves
+// like a standard Morpheus REST endpoint:
+//
+//	GET    /api/<collection>          list, optionally filtered by ?name=
+//	GET    /api/<collection>/:id      show
+//	POST   /api/<collection>          create from the {"<singular>": {...}} payload
+//	PUT    /api/<collection>/:id      merge the {"<singular>": {...}} payload
+//	DELETE /api/<collection>/:id      delete
+//
+// Requests to /api/<collection>/:id/<action> are routed to the action
+// handlers registered on the collection, falling back to merging the
+// payload into the record.
+type mockServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	collections []*mockCollection
+	failures    []*mockFailure
+	requests    []mockRequest
+}
+
+// mockCollection is a single REST collection held by the mockServer.
+type mockCollection struct {
+	path     string
+	singular string
+	plural   string
+	defaults map[string]interface{}
+	actions  map[string]mockAction
+	onSave   []func(record map[string]interface{})
+	onCreate []func(record map[string]interface{}, body map[string]interface{})
+	onUpdate []func(record map[string]interface{}, body map[string]interface{})
+	records  map[int64]map[string]interface{}
+
+	// unwrapped collections are created from the whole request body
+	// rather than the {"<singular>": {...}} payload
+	unwrapped bool
+
+	// member builds the response to a GET of a record when set, for
+	// endpoints returning more than the {"<singular>": {...}} payload
+	member func(record map[string]interface{}) map[string]interface{}
+}
+
+// mockAction handles a request made against a member of a collection such
+// as POST /api/instances/1/stop. The record may be modified in place.
+type mockAction func(record map[string]interface{}, body map[string]interface{}) (int, interface{})
+
+// mockFailure forces the server to respond with the given status code to
+// the next count requests that match method and path.
+type mockFailure struct {
+	method string
+	path   string
+	status int
+	count  int
+}
+
+// mockRequest is a record of a request received by the mockServer.
+type mockRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// mockCollections lists the API endpoints the mockServer serves by default
+// along with the JSON root keys used in their payloads.
+var mockCollections = []struct {
+	path     string
+	singular string
+	plural   string
+	defaults map[string]interface{}
+}{
+	{"/api/accounts", "account", "accounts", nil},
+	{"/api/apps", "app", "apps", map[string]interface{}{"status": "running"}},
+	{"/api/blueprints", "blueprint", "blueprints", nil},
+	{"/api/boot-scripts", "bootScript", "bootScripts", nil},
+	{"/api/budgets", "budget", "budgets", nil},
+	{"/api/catalog-item-types", "catalogItemType", "catalogItemTypes", nil},
+	{"/api/clusters", "cluster", "clusters", map[string]interface{}{"status": "ok"}},
+	{"/api/credentials", "credential", "credentials", nil},
+	{"/api/environments", "environment", "environments", nil},
+	{"/api/execute-schedules", "schedule", "schedules", nil},
+	{"/api/groups", "group", "groups", nil},
+	{"/api/instances", "instance", "instances", map[string]interface{}{"status": "running"}},
+	{"/api/integrations", "integration", "integrations", nil},
+	{"/api/key-pairs", "keyPair", "keyPairs", nil},
+	{"/api/library/cluster-layouts", "layout", "layouts", nil},
+	{"/api/library/container-scripts", "containerScript", "containerScripts", nil},
+	{"/api/library/container-templates", "containerTemplate", "containerTemplates", nil},
+	{"/api/library/container-types", "containerType", "containerTypes", nil},
+	{"/api/library/instance-types", "instanceType", "instanceTypes", nil},
+	{"/api/library/layouts", "instanceTypeLayout", "instanceTypeLayouts", nil},
+	{"/api/library/option-type-lists", "optionTypeList", "optionTypeLists", nil},
+	{"/api/library/option-types", "optionType", "optionTypes", nil},
+	{"/api/library/spec-templates", "specTemplate", "specTemplates", nil},
+	{"/api/monitoring/contacts", "contact", "contacts", nil},
+	{"/api/networks/domains", "networkDomain", "networkDomains", map[string]interface{}{"active": true}},
+	{"/api/policies", "policy", "policies", nil},
+	{"/api/power-schedules", "schedule", "schedules", nil},
+	{"/api/preseed-scripts", "preseedScript", "preseedScripts", nil},
+	{"/api/price-sets", "priceSet", "priceSets", nil},
+	{"/api/prices", "price", "prices", map[string]interface{}{"active": true}},
+	{"/api/roles", "role", "roles", nil},
+	{"/api/scale-thresholds", "scaleThreshold", "scaleThresholds", nil},
+	{"/api/servers", "server", "servers", nil},
+	{"/api/service-plans", "servicePlan", "servicePlans", nil},
+	{"/api/snapshots", "snapshot", "snapshots", map[string]interface{}{"status": "complete"}},
+	{"/api/task-sets", "taskSet", "taskSets", nil},
+	{"/api/tasks", "task", "tasks", nil},
+	{"/api/user-groups", "userGroup", "userGroups", nil},
+	{"/api/user-sources", "userSource", "userSources", nil},
+	{"/api/users", "user", "users", nil},
+	{"/api/wiki/pages", "page", "pages", nil},
+	{"/api/zones", "zone", "zones", map[string]interface{}{"status": "ok"}},
+}
+
+// newMockServer starts a mockServer that is shut down when the test ends.
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+	s := &mockServer{}
+	for _, c := range mockCollections {
+		s.addCollection(c.path, c.singular, c.plural, c.defaults)
+	}
+	s.addSaveHook("/api/zones", mockAccountOwner)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// addCollection registers a collection served at path. Records created in
+// the collection are initialized with a copy of defaults.
+func (s *mockServer) addCollection(path string, singular string, plural string, defaults map[string]interface{}) *mockCollection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newCollection(path, singular, plural, defaults)
+}
+
+// newCollection registers a collection like addCollection from a hook or
+// an action, which run with s.mu held.
+func (s *mockServer) newCollection(path string, singular string, plural string, defaults map[string]interface{}) *mockCollection {
+	c := &mockCollection{
+		path:     path,
+		singular: singular,
+		plural:   plural,
+		defaults: defaults,
+		actions:  make(map[string]mockAction),
+		records:  make(map[int64]map[string]interface{}),
+	}
+	s.collections = append(s.collections, c)
+	// longest paths first so nested collections win over their parents
+	sort.SliceStable(s.collections, func(i, j int) bool {
+		return len(s.collections[i].path) > len(s.collections[j].path)
+	})
+	return c
+}
+
+// collection returns the collection registered at path.
+func (s *mockServer) collection(path string) *mockCollection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.collections {
+		if c.path == path {
+			return c
+		}
+	}
+	panic(fmt.Sprintf("mock server has no collection for %s", path))
+}
+
+// addAction registers a handler for requests made to path/:id/name.
+func (s *mockServer) addAction(path string, name string, action mockAction) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.actions[name] = action
+}
+
+// seed adds a record to the collection at path and returns its id.
+func (s *mockServer) seed(path string, record map[string]interface{}) int64 {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(c, record)
+}
+
+// record returns the record with the given id or nil if it does not exist.
+func (s *mockServer) record(path string, id int64) map[string]interface{} {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return c.records[id]
+}
+
+// remove deletes a record out of band, like a user deleting an object
+// in the Morpheus UI.
+func (s *mockServer) remove(path string, id int64) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(c.records, id)
+}
+
+// addSaveHook registers a function called with every record of the
+// collection at path after it is created or updated, to mimic values the
+// API computes such as password hashes.
+func (s *mockServer) addSaveHook(path string, hook func(record map[string]interface{})) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.onSave = append(c.onSave, hook)
+}
+
+// addCreateHook registers a function called with every record created in
+// the collection at path along with the whole request body, to mimic the
+// API applying settings sent outside of the record payload such as the
+// zoneId of a new instance.
+func (s *mockServer) addCreateHook(path string, hook func(record map[string]interface{}, body map[string]interface{})) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.onCreate = append(c.onCreate, hook)
+}
+
+// addUpdateHook registers a function called with every record updated in
+// the collection at path along with the whole request body, to mimic the
+// API applying settings sent outside of the record payload.
+func (s *mockServer) addUpdateHook(path string, hook func(record map[string]interface{}, body map[string]interface{})) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.onUpdate = append(c.onUpdate, hook)
+}
+
+// setMemberPayload makes a GET of a record in the collection at path
+// respond with the payload built by member, like the API does for roles
+// which return their permissions next to the role.
+func (s *mockServer) setMemberPayload(path string, member func(record map[string]interface{}) map[string]interface{}) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.member = member
+}
+
+// setUnwrapped makes the collection at path accept create requests
+// without the root key, like the API does for apps deployed from a
+// blueprint.
+func (s *mockServer) setUnwrapped(path string) {
+	c := s.collection(path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.unwrapped = true
+}
+
+// failNext makes the next count requests for method and path fail with
+// the given HTTP status code.
+func (s *mockServer) failNext(method string, path string, status int, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &mockFailure{method: method, path: path, status: status, count: count})
+}
+
+// requestsFor returns the requests received for method and path.
+func (s *mockServer) requestsFor(method string, path string) []mockRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []mockRequest
+	for _, r := range s.requests {
+		if r.Method == method && r.Path == path {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+func (s *mockServer) insert(c *mockCollection, record map[string]interface{}) int64 {
+	s.nextID++
+	id := s.nextID
+	stored := make(map[string]interface{})
+	for k, v := range c.defaults {
+		stored[k] = v
+	}
+	for k, v := range copyMockRecord(record) {
+		stored[k] = v
+	}
+	stored["id"] = id
+	c.records[id] = stored
+	for _, hook := range c.onSave {
+		hook(stored)
+	}
+	return id
+}
+
+func (s *mockServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var body map[string]interface{}
+	if r.Body != nil && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	s.requests = append(s.requests, mockRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	for _, f := range s.failures {
+		if f.count > 0 && f.method == r.Method && f.path == r.URL.Path {
+			f.count--
+			writeMockJSON(w, f.status, map[string]interface{}{"success": false, "msg": http.StatusText(f.status)})
+			return
+		}
+	}
+
+	if r.URL.Path == "/oauth/token" {
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":  "mock-access-token",
+			"refresh_token": "mock-refresh-token",
+			"expires_in":    86400,
+			"scope":         "write",
+		})
+		return
+	}
+
+	for _, c := range s.collections {
+		if r.URL.Path != c.path && !strings.HasPrefix(r.URL.Path, c.path+"/") {
+			continue
+		}
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, c.path), "/"), "/")
+		if parts[0] == "" {
+			s.serveCollection(w, r, c, body)
+			return
+		}
+		id, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		record, ok := c.records[id]
+		if !ok {
+			writeMockJSON(w, http.StatusNotFound, map[string]interface{}{"success": false, "msg": fmt.Sprintf("%s not found", c.singular)})
+			return
+		}
+		if len(parts) > 1 {
+			s.serveAction(w, c, record, parts[1], body)
+			return
+		}
+		s.serveMember(w, r, c, id, record, body)
+		return
+	}
+	writeMockJSON(w, http.StatusNotFound, map[string]interface{}{"success": false, "msg": "Unable to find specified resource"})
+}
+
+func (s *mockServer) serveCollection(w http.ResponseWriter, r *http.Request, c *mockCollection, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		name := r.URL.Query().Get("name")
+		authority := r.URL.Query().Get("authority")
+		ids := make([]int64, 0, len(c.records))
+		for id, record := range c.records {
+			if (name == "" || record["name"] == name) && (authority == "" || record["authority"] == authority) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		records := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			records = append(records, c.records[id])
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{
+			c.plural: records,
+			"meta":   map[string]interface{}{"total": len(records), "size": len(records), "offset": 0},
+		})
+	case http.MethodPost:
+		payload, _ := body[c.singular].(map[string]interface{})
+		if payload == nil && c.unwrapped {
+			payload = body
+		}
+		if payload == nil {
+			writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "msg": fmt.Sprintf("missing %s payload", c.singular)})
+			return
+		}
+		id := s.insert(c, payload)
+		for _, hook := range c.onCreate {
+			hook(c.records[id], copyMockRecord(body))
+		}
+		// some endpoints, such as prices, only return the id of the record
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"success": true, "id": id, c.singular: c.records[id]})
+	default:
+		writeMockJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"success": false})
+	}
+}
+
+func (s *mockServer) serveMember(w http.ResponseWriter, r *http.Request, c *mockCollection, id int64, record map[string]interface{}, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		if c.member != nil {
+			writeMockJSON(w, http.StatusOK, c.member(record))
+			return
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{c.singular: record})
+	case http.MethodPut:
+		if payload, ok := body[c.singular].(map[string]interface{}); ok {
+			mergeMockRecord(record, payload)
+		}
+		for _, hook := range c.onUpdate {
+			hook(record, copyMockRecord(body))
+		}
+		for _, hook := range c.onSave {
+			hook(record)
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"success": true, c.singular: record})
+	case http.MethodDelete:
+		delete(c.records, id)
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+	default:
+		writeMockJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"success": false})
+	}
+}
+
+func (s *mockServer) serveAction(w http.ResponseWriter, c *mockCollection, record map[string]interface{}, name string, body map[string]interface{}) {
+	if action, ok := c.actions[name]; ok {
+		status, payload := action(record, body)
+		writeMockJSON(w, status, payload)
+		return
+	}
+	if payload, ok := body[c.singular].(map[string]interface{}); ok {
+		for k, v := range payload {
+			record[k] = v
+		}
+	}
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"success": true, c.singular: record})
+}
+
+// mockAccountOwner assigns the record to the tenant in its accountId or
+// account.id, defaulting to the master tenant. The API accepts the id as a
+// string but always returns a number.
+func mockAccountOwner(record map[string]interface{}) {
+	var id int64 = 1
+	switch v := record["accountId"].(type) {
+	case string:
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			id = parsed
+		}
+	case float64:
+		id = int64(v)
+	}
+	record["accountId"] = id
+	record["account"] = map[string]interface{}{"id": id}
+}
+
+// copyMockRecord returns a deep copy of record so stored records do not
+// share nested values with the logged requests.
+func copyMockRecord(record map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(record)
+	var copied map[string]interface{}
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+// mergeMockRecord merges an update payload into record. Nested settings
+// such as config are merged one level deep, like the API does.
+func mergeMockRecord(record map[string]interface{}, payload map[string]interface{}) {
+	for k, v := range copyMockRecord(payload) {
+		existing, ok := record[k].(map[string]interface{})
+		update, isMap := v.(map[string]interface{})
+		if ok && isMap && k == "config" {
+			for ck, cv := range update {
+				existing[ck] = cv
+			}
+			continue
+		}
+		record[k] = v
+	}
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
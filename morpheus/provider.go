@@ -2,13 +2,15 @@ package morpheus
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:        schema.TypeString,
@@ -42,6 +44,93 @@ func Provider() *schema.Provider {
 				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_API_PASSWORD", nil),
 				ConflictsWith: []string{"access_token"},
 			},
+
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to skip verification of the TLS certificate presented by the Morpheus appliance",
+				DefaultFunc: schema.EnvDefaultFunc("MORPHEUS_INSECURE", false),
+			},
+
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded CA bundle used to verify the TLS certificate of the Morpheus appliance",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded CA bundle used to verify the TLS certificate of the Morpheus appliance",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Path to, or PEM encoded content of, the client certificate presented to the Morpheus appliance",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_CLIENT_CERT", nil),
+				RequiredWith: []string{"client_key"},
+			},
+
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Path to, or PEM encoded content of, the private key of the client certificate",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_CLIENT_KEY", nil),
+				RequiredWith: []string{"client_cert"},
+			},
+
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the HTTP proxy used to reach the Morpheus appliance. Defaults to the standard HTTPS_PROXY and NO_PROXY environment variables",
+				DefaultFunc: schema.EnvDefaultFunc("MORPHEUS_PROXY_URL", nil),
+			},
+
+			"tenant_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "The id of the subtenant to manage. Requires a master tenant user with access to the subtenant",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_TENANT_ID", nil),
+				ConflictsWith: []string{"tenant_name"},
+			},
+
+			"tenant_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The name of the subtenant to manage. Requires a master tenant user with access to the subtenant",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_TENANT_NAME", nil),
+				ConflictsWith: []string{"tenant_id"},
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of times a request that failed with a transient error (HTTP 429, 5xx or a connection error) is retried. Set to 0 to disable retries",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The minimum number of seconds to wait before retrying a failed request",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of seconds to wait before retrying a failed request",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_RETRY_WAIT_MAX", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -50,17 +139,22 @@ func Provider() *schema.Provider {
 			"morpheus_ansible_playbook_task":            resourceAnsiblePlaybookTask(),
 			"morpheus_ansible_tower_integration":        resourceAnsibleTowerIntegration(),
 			"morpheus_api_option_list":                  resourceApiOptionList(),
+			"morpheus_app":                              resourceApp(),
 			"morpheus_app_blueprint_catalog_item":       resourceAppBlueprintCatalogItem(),
 			"morpheus_arm_app_blueprint":                resourceArmAppBlueprint(),
 			"morpheus_arm_spec_template":                resourceArmSpecTemplate(),
 			"morpheus_aws_cloud":                        resourceAWSCloud(),
+			"morpheus_azure_ad_identity_source":         resourceAzureADIdentitySource(),
+			"morpheus_azure_cloud":                      resourceAzureCloud(),
 			"morpheus_backup_creation_policy":           resourceBackupCreationPolicy(),
 			"morpheus_backup_setting":                   resourceBackupSetting(),
 			"morpheus_boot_script":                      resourceBootScript(),
 			"morpheus_budget_policy":                    resourceBudgetPolicy(),
 			"morpheus_checkbox_option_type":             resourceCheckboxOptionType(),
+			"morpheus_cloud":                            resourceCloud(),
 			"morpheus_cloud_formation_app_blueprint":    resourceCloudFormationAppBlueprint(),
 			"morpheus_cloud_formation_spec_template":    resourceCloudFormationSpecTemplate(),
+			"morpheus_cluster":                          resourceCluster(),
 			"morpheus_cluster_layout":                   resourceClusterLayout(),
 			"morpheus_cluster_resource_name_policy":     resourceClusterResourceNamePolicy(),
 			"morpheus_contact":                          resourceContact(),
@@ -73,19 +167,23 @@ func Provider() *schema.Provider {
 			"morpheus_file_template":                    resourceFileTemplate(),
 			"morpheus_git_integration":                  resourceGitIntegration(),
 			"morpheus_groovy_script_task":               resourceGroovyScriptTask(),
+			"morpheus_gcp_cloud":                        resourceGCPCloud(),
 			"morpheus_group":                            resourceMorpheusGroup(),
 			"morpheus_helm_app_blueprint":               resourceHelmAppBlueprint(),
 			"morpheus_helm_spec_template":               resourceHelmSpecTemplate(),
 			"morpheus_hidden_option_type":               resourceHiddenOptionType(),
 			"morpheus_hostname_policy":                  resourceHostNamePolicy(),
+			"morpheus_instance":                         resourceInstance(),
 			"morpheus_instance_catalog_item":            resourceInstanceCatalogItem(),
 			"morpheus_instance_layout":                  resourceInstanceLayout(),
 			"morpheus_instance_name_policy":             resourceInstanceNamePolicy(),
+			"morpheus_instance_snapshot":                resourceInstanceSnapshot(),
 			"morpheus_instance_type":                    resourceInstanceType(),
 			"morpheus_javascript_task":                  resourceJavaScriptTask(),
 			//			"morpheus_license":                       resourceLicense(),
 			"morpheus_kubernetes_app_blueprint":   resourceKubernetesAppBlueprint(),
 			"morpheus_kubernetes_spec_template":   resourceKubernetesSpecTemplate(),
+			"morpheus_ldap_identity_source":       resourceLdapIdentitySource(),
 			"morpheus_manual_option_list":         resourceManualOptionList(),
 			"morpheus_max_containers_policy":      resourceMaxContainersPolicy(),
 			"morpheus_max_cores_policy":           resourceMaxCoresPolicy(),
@@ -98,6 +196,9 @@ func Provider() *schema.Provider {
 			"morpheus_network_quota_policy":       resourceNetworkQuotaPolicy(),
 			"morpheus_node_type":                  resourceNodeType(),
 			"morpheus_number_option_type":         resourceNumberOptionType(),
+			"morpheus_nutanix_prism_cloud":        resourceNutanixPrismCloud(),
+			"morpheus_okta_identity_source":       resourceOktaIdentitySource(),
+			"morpheus_openstack_cloud":            resourceOpenStackCloud(),
 			"morpheus_operational_workflow":       resourceOperationalWorkflow(),
 			"morpheus_password_option_type":       resourcePasswordOptionType(),
 			"morpheus_power_schedule_policy":      resourcePowerSchedulePolicy(),
@@ -113,30 +214,35 @@ func Provider() *schema.Provider {
 			"morpheus_restart_task":               resourceRestartTask(),
 			"morpheus_router_quota_policy":        resourceRouterQuotaPolicy(),
 			"morpheus_ruby_script_task":           resourceRubyScriptTask(),
+			"morpheus_saml_identity_source":       resourceSamlIdentitySource(),
 			"morpheus_scale_threshold":            resourceScaleThreshold(),
 			"morpheus_script_template":            resourceScriptTemplate(),
 			"morpheus_select_list_option_type":    resourceSelectListOptionType(),
+			"morpheus_server":                     resourceServer(),
 			"morpheus_service_plan":               resourceServicePlan(),
 			"morpheus_shell_script_task":          resourceShellScriptTask(),
 			"morpheus_tag_policy":                 resourceTagPolicy(),
 			"morpheus_task_job":                   resourceTaskJob(),
 			"morpheus_tenant":                     resourceTenant(),
+			"morpheus_tenant_role":                resourceTenantRole(),
 			"morpheus_terraform_app_blueprint":    resourceTerraformAppBlueprint(),
 			"morpheus_terraform_spec_template":    resourceTerraformSpecTemplate(),
 			"morpheus_text_option_type":           resourceTextOptionType(),
 			"morpheus_textarea_option_type":       resourceTextAreaOptionType(),
 			"morpheus_typeahead_option_type":      resourceTypeAheadOptionType(),
+			"morpheus_user":                       resourceUser(),
 			"morpheus_user_creation_policy":       resourceUserCreationPolicy(),
+			"morpheus_user_group":                 resourceUserGroup(),
 			"morpheus_user_group_creation_policy": resourceUserGroupCreationPolicy(),
-			//			"morpheus_user_role":                  resourceUserRole(),
-			"morpheus_vro_integration":       resourceVrealizeOrchestratorIntegration(),
-			"morpheus_vro_task":              resourceVrealizeOrchestratorTask(),
-			"morpheus_vsphere_cloud":         resourceVsphereCloud(),
-			"morpheus_vsphere_instance":      resourceVsphereInstance(),
-			"morpheus_wiki_page":             resourceWikiPage(),
-			"morpheus_workflow_catalog_item": resourceWorkflowCatalogItem(),
-			"morpheus_workflow_policy":       resourceWorkflowPolicy(),
-			"morpheus_write_attributes_task": resourceWriteAttributesTask(),
+			"morpheus_user_role":                  resourceUserRole(),
+			"morpheus_vro_integration":            resourceVrealizeOrchestratorIntegration(),
+			"morpheus_vro_task":                   resourceVrealizeOrchestratorTask(),
+			"morpheus_vsphere_cloud":              resourceVsphereCloud(),
+			"morpheus_vsphere_instance":           resourceVsphereInstance(),
+			"morpheus_wiki_page":                  resourceWikiPage(),
+			"morpheus_workflow_catalog_item":      resourceWorkflowCatalogItem(),
+			"morpheus_workflow_policy":            resourceWorkflowPolicy(),
+			"morpheus_write_attributes_task":      resourceWriteAttributesTask(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"morpheus_budget":           dataSourceMorpheusBudget(),
@@ -179,6 +285,8 @@ func Provider() *schema.Provider {
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+	requireTenantSupport(p.ResourcesMap)
+	return p
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -187,7 +295,33 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		AccessToken: d.Get("access_token").(string),
 		Username:    d.Get("username").(string),
 		Password:    d.Get("password").(string),
-		//Insecure:                d.Get("insecure").(bool), //.(bool),
+		Insecure:    d.Get("insecure").(bool),
+		CACertFile:  d.Get("ca_cert_file").(string),
+		CACertPEM:   d.Get("ca_cert_pem").(string),
+		ClientCert:  d.Get("client_cert").(string),
+		ClientKey:   d.Get("client_key").(string),
+		ProxyURL:    d.Get("proxy_url").(string),
+
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryWaitMin: time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		RetryWaitMax: time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+
+		TenantID:   int64(d.Get("tenant_id").(int)),
+		TenantName: d.Get("tenant_name").(string),
+	}
+	if config.RetryWaitMax < config.RetryWaitMin {
+		return nil, diag.Errorf("retry_wait_max must be greater than or equal to retry_wait_min")
+	}
+	client, diags := config.Client()
+	if diags.HasError() {
+		return nil, diags
+	}
+	// stop the API relay along with the provider
+	if stop, ok := schema.StopContext(ctx); ok {
+		go func() {
+			<-stop.Done()
+			config.relay.Close()
+		}()
 	}
-	return config.Client()
+	return &providerMeta{client: client, tenantID: config.TenantID}, diags
 }
@@ -0,0 +1,280 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccProviderFactories is used by acceptance tests to run the provider
+// in-process. Acceptance tests only run when TF_ACC is set and use the
+// mock server unless they are explicitly pointed at a real appliance.
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"morpheus": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testAccMockProviderConfig returns a provider block directing requests
+// to the mock server.
+func testAccMockProviderConfig(s *mockServer) string {
+	return fmt.Sprintf(`
+provider "morpheus" {
+  url          = %q
+  access_token = "mock-access-token"
+}
+`, s.URL)
+}
+
+// testAccCheckMockDestroyed verifies every resource of the given type in
+// the state was removed from the mock server.
+func testAccCheckMockDestroyed(s *mockServer, resourceType string, path string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if s.record(path, toInt64(rs.Primary.ID)) != nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testProviderMeta configures the provider against the mock server and
+// returns the meta value passed to resource CRUD functions.
+func testProviderMeta(t *testing.T, s *mockServer) interface{} {
+	t.Helper()
+	p := Provider()
+	raw := map[string]interface{}{
+		"url":          s.URL,
+		"access_token": "mock-access-token",
+	}
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	return p.Meta()
+}
+
+// testResource returns the schema of a resource registered in Provider().
+func testResource(t *testing.T, name string) *schema.Resource {
+	t.Helper()
+	r, ok := Provider().ResourcesMap[name]
+	if !ok {
+		t.Fatalf("resource %s is not registered with the provider", name)
+	}
+	return r
+}
+
+// testResourceApply plans the configuration against the current state and
+// applies the result, the same way Terraform does for create and update.
+func testResourceApply(t *testing.T, meta interface{}, name string, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	r := testResource(t, name)
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning %s: %s", name, err)
+	}
+	if diff == nil {
+		return state
+	}
+	diff.RawConfig = testResourceRawConfig(t, r, config)
+	newState, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error applying %s: %v", name, diags)
+	}
+	if newState == nil || newState.ID == "" {
+		t.Fatalf("%s has no ID after apply", name)
+	}
+	return newState
+}
+
+// testResourceRawConfig converts the configuration to the value Terraform
+// sends the provider, for resources that tell unset attributes apart from
+// zero values with GetRawConfig.
+func testResourceRawConfig(t *testing.T, r *schema.Resource, config map[string]interface{}) cty.Value {
+	t.Helper()
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("error converting the configuration: %s", err)
+	}
+	return raw
+}
+
+// testResourceApplyError plans and applies the configuration like
+// testResourceApply, failing the test unless the apply fails. The error
+// is returned for the test to check.
+func testResourceApplyError(t *testing.T, meta interface{}, name string, state *terraform.InstanceState, config map[string]interface{}) string {
+	t.Helper()
+	r := testResource(t, name)
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning %s: %s", name, err)
+	}
+	diff.RawConfig = testResourceRawConfig(t, r, config)
+	_, diags := r.Apply(ctx, state, diff, meta)
+	if !diags.HasError() {
+		t.Fatalf("expected applying %s to fail", name)
+	}
+	return diags[0].Summary
+}
+
+// testResourcePlanError plans the resource, expecting planning to fail, and
+// returns the error.
+func testResourcePlanError(t *testing.T, meta interface{}, name string, state *terraform.InstanceState, config map[string]interface{}) string {
+	t.Helper()
+	r := testResource(t, name)
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err == nil {
+		t.Fatalf("expected planning %s to fail", name)
+	}
+	return err.Error()
+}
+
+// testResourceRefresh reads the resource and returns the refreshed state.
+// A nil state means the resource was removed from state by Read.
+func testResourceRefresh(t *testing.T, meta interface{}, name string, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+	r := testResource(t, name)
+	newState, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing %s: %v", name, diags)
+	}
+	return newState
+}
+
+// testResourceImport imports the resource by ID and refreshes it.
+func testResourceImport(t *testing.T, meta interface{}, name string, id string) *terraform.InstanceState {
+	t.Helper()
+	r := testResource(t, name)
+	if r.Importer == nil {
+		t.Fatalf("%s does not support import", name)
+	}
+	ctx := context.Background()
+	data := r.Data(&terraform.InstanceState{ID: id})
+	imported, err := r.Importer.StateContext(ctx, data, meta)
+	if err != nil {
+		t.Fatalf("error importing %s: %s", name, err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported %s, got %d", name, len(imported))
+	}
+	return testResourceRefresh(t, meta, name, imported[0].State())
+}
+
+// testResourceDestroy deletes the resource.
+func testResourceDestroy(t *testing.T, meta interface{}, name string, state *terraform.InstanceState) {
+	t.Helper()
+	r := testResource(t, name)
+	newState, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta)
+	if diags.HasError() {
+		t.Fatalf("error destroying %s: %v", name, diags)
+	}
+	if newState != nil && newState.ID != "" {
+		t.Fatalf("%s still has ID %s after destroy", name, newState.ID)
+	}
+}
+
+// testResourcePlanEmpty fails the test if planning the configuration
+// against the state would produce any changes.
+func testResourcePlanEmpty(t *testing.T, meta interface{}, name string, state *terraform.InstanceState, config map[string]interface{}) {
+	t.Helper()
+	r := testResource(t, name)
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning %s: %s", name, err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected empty plan for %s, got: %#v", name, diff.Attributes)
+	}
+}
+
+// testResourceLifecycle exercises the full create, read, update, import
+// and delete cycle of a resource against the mock server. The record is
+// expected to live in the collection at path.
+func testResourceLifecycle(t *testing.T, s *mockServer, name string, path string, create map[string]interface{}, update map[string]interface{}) {
+	t.Helper()
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, name, nil, create)
+	if s.record(path, toInt64(state.ID)) == nil {
+		t.Fatalf("%s %s was not created on the server", name, state.ID)
+	}
+
+	state = testResourceRefresh(t, meta, name, state)
+	if state == nil {
+		t.Fatalf("%s was removed from state after create", name)
+	}
+	testResourcePlanEmpty(t, meta, name, state, create)
+
+	if update != nil {
+		state = testResourceApply(t, meta, name, state, update)
+		state = testResourceRefresh(t, meta, name, state)
+		testResourcePlanEmpty(t, meta, name, state, update)
+	}
+
+	if testResource(t, name).Importer != nil {
+		imported := testResourceImport(t, meta, name, state.ID)
+		if imported == nil || imported.ID != state.ID {
+			t.Fatalf("expected imported %s to have ID %s, got %v", name, state.ID, imported)
+		}
+	}
+
+	testResourceDestroy(t, meta, name, state)
+	if s.record(path, toInt64(state.ID)) != nil {
+		t.Fatalf("%s %s still exists on the server", name, state.ID)
+	}
+}
+
+func TestProvider_resourcesNotFound(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	for name, r := range Provider().ResourcesMap {
+		if r.DeleteContext == nil {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "404"}
+			if newState := testResourceRefresh(t, meta, name, state); newState != nil {
+				t.Fatalf("expected %s to be removed from state, got ID %q", name, newState.ID)
+			}
+			testResourceDestroy(t, meta, name, state)
+		})
+	}
+}
+
+// testResourceDisappears creates a resource, deletes it out of band and
+// verifies Read removes it from state and Delete still succeeds.
+func testResourceDisappears(t *testing.T, s *mockServer, name string, path string, config map[string]interface{}) {
+	t.Helper()
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, name, nil, config)
+	s.remove(path, toInt64(state.ID))
+
+	if newState := testResourceRefresh(t, meta, name, state); newState != nil {
+		t.Fatalf("expected %s to be removed from state, got ID %q", name, newState.ID)
+	}
+	testResourceDestroy(t, meta, name, state)
+}
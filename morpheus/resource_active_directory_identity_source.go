@@ -2,25 +2,99 @@ package morpheus
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"strings"
 
-	"log"
-
-	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var activeDirectoryIdentitySource = identitySourceType{
+	code:        "activeDirectory",
+	title:       "Active Directory",
+	description: "active directory",
+	groupPrefix: "active_directory_group",
+	groupName:   "The name of the active directory role to map to",
+	groupFqn:    "The fully qualified name of the active directory role to map to (i.e. - CN=Administrators,CN=Builtin,DC=contoso,DC=com)",
+	settings: []identitySourceSetting{
+		{attribute: "ad_server", field: "url"},
+		{attribute: "domain", field: "domain"},
+		{attribute: "use_ssl", field: "useSSL", onOff: true},
+		{attribute: "binding_username", field: "bindingUsername"},
+		{attribute: "binding_password", field: "bindingPassword", secret: true},
+		{attribute: "required_group", field: "requiredGroup"},
+		{attribute: "search_member_groups", field: "searchMemberGroups"},
+	},
+}
+
 func resourceActiveDirectoryIdentitySource() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Provides an active directory identity source resource",
 		CreateContext: resourceActiveDirectoryIdentitySourceCreate,
 		ReadContext:   resourceActiveDirectoryIdentitySourceRead,
 		UpdateContext: resourceActiveDirectoryIdentitySourceUpdate,
-		DeleteContext: resourceActiveDirectoryIdentitySourceDelete,
+		DeleteContext: resourceIdentitySourceDelete,
+		CustomizeDiff: customizeIdentitySourceRoleMappingDiff(activeDirectoryIdentitySource.groupPrefix),
+
+		Schema: identitySourceSchema(activeDirectoryIdentitySource, map[string]*schema.Schema{
+			"ad_server": {
+				Type:        schema.TypeString,
+				Description: "The IP address or hostname of the active directory domain controller",
+				Required:    true,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the active directory domain",
+				Required:    true,
+			},
+			"use_ssl": {
+				Type:        schema.TypeBool,
+				Description: "Whether to use SSL when connecting to the domain controller",
+				Optional:    true,
+				Computed:    true,
+			},
+			"binding_username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to authenticate to the domain",
+				Required:    true,
+			},
+			"binding_password": {
+				Type:             schema.TypeString,
+				Description:      "The password of the account used to authenticate to the domain",
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"required_group": {
+				Type:        schema.TypeString,
+				Description: "The active directory group users must be in to access Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"search_member_groups": {
+				Type:        schema.TypeBool,
+				Description: "Whether groups nested inside the required group will also be included",
+				Optional:    true,
+				Computed:    true,
+			},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceActiveDirectoryIdentitySourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceActiveDirectoryIdentitySourceStateUpgradeV0,
+				Version: 0,
+			},
+		},
+	}
+}
 
+// resourceActiveDirectoryIdentitySourceV0 is the schema before role
+// mappings were keyed by active_directory_group_fqn. It is a copy of the
+// schema at the time and must not change.
+func resourceActiveDirectoryIdentitySourceV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -65,16 +139,11 @@ func resourceActiveDirectoryIdentitySource() *schema.Resource {
 				Required:    true,
 			},
 			"binding_password": {
-				Type:        schema.TypeString,
-				Description: "The password of the account used to authenticate to the domain",
-				Required:    true,
-				Sensitive:   true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					h := sha256.New()
-					h.Write([]byte(new))
-					sha256_hash := hex.EncodeToString(h.Sum(nil))
-					return strings.ToLower(old) == strings.ToLower(sha256_hash)
-				},
+				Type:             schema.TypeString,
+				Description:      "The password of the account used to authenticate to the domain",
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
 			},
 			"required_group": {
 				Type:        schema.TypeString,
@@ -131,237 +200,44 @@ func resourceActiveDirectoryIdentitySource() *schema.Resource {
 				},
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-	}
-}
-
-func resourceActiveDirectoryIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	identitySource := make(map[string]interface{})
-
-	identitySource["name"] = d.Get("name").(string)
-	identitySource["description"] = d.Get("description").(string)
-	identitySource["type"] = "activeDirectory"
-
-	config := make(map[string]interface{})
-	config["url"] = d.Get("ad_server").(string)
-	config["domain"] = d.Get("domain").(string)
-	if d.Get("use_ssl").(bool) {
-		config["useSSL"] = "on"
-	} else {
-		config["useSSL"] = "off"
-	}
-	config["bindingUsername"] = d.Get("binding_username").(string)
-	config["bindingPassword"] = d.Get("binding_password").(string)
-	config["requiredGroup"] = d.Get("required_group").(string)
-	config["searchMemberGroups"] = d.Get("search_member_groups").(bool)
-	config["allowCustomMappings"] = d.Get("enable_role_mapping_permission").(bool)
-
-	identitySource["config"] = config
-
-	defaultAccountRole := make(map[string]interface{})
-	defaultAccountRole["id"] = d.Get("default_account_role_id").(int)
-	identitySource["defaultAccountRole"] = defaultAccountRole
-
-	// Role Mappings
-	if d.Get("role_mapping") != "" {
-		identitySource["roleMappings"] = parseRoleMappings(d.Get("role_mapping").(*schema.Set))
-	}
-
-	req := &morpheus.Request{
-		Body: map[string]interface{}{
-			"userSource": identitySource,
-		},
 	}
-
-	resp, err := client.CreateIdentitySource(int64(d.Get("tenant_id").(int)), req)
-	if err != nil {
-		log.Printf("API FAILURE: %s - %s", resp, err)
-		return diag.FromErr(err)
-	}
-	log.Printf("API RESPONSE: %s", resp)
-
-	result := resp.Result.(*morpheus.CreateIdentitySourceResult)
-	identitySourceResult := result.IdentitySource
-	// Successfully created resource, now set id
-	d.SetId(int64ToString(identitySourceResult.ID))
-
-	resourceActiveDirectoryIdentitySourceRead(ctx, d, meta)
-	return diags
 }
 
-func resourceActiveDirectoryIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	id := d.Id()
-	name := d.Get("name").(string)
-
-	// lookup by name if we do not have an id yet
-	var resp *morpheus.Response
-	var err error
-	if id == "" && name != "" {
-		resp, err = client.FindIdentitySourceByName(name)
-	} else if id != "" {
-		resp, err = client.GetIdentitySource(toInt64(id), &morpheus.Request{})
-	} else {
-		return diag.Errorf("Identity source cannot be read without name or id")
-	}
-
-	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
-		} else {
-			log.Printf("API FAILURE: %s - %s", resp, err)
-			return diag.FromErr(err)
+// resourceActiveDirectoryIdentitySourceStateUpgradeV0 keeps a single role
+// mapping per active directory group, the last one like the appliance does,
+// so existing state matches the mappings keyed by group.
+func resourceActiveDirectoryIdentitySourceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	mappings, ok := rawState["role_mapping"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+	indexes := make(map[string]int)
+	upgraded := make([]interface{}, 0, len(mappings))
+	for _, raw := range mappings {
+		mapping, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
 		}
+		fqn, _ := mapping["active_directory_group_fqn"].(string)
+		if i, ok := indexes[fqn]; ok {
+			upgraded[i] = mapping
+			continue
+		}
+		indexes[fqn] = len(upgraded)
+		upgraded = append(upgraded, mapping)
 	}
-	log.Printf("API RESPONSE: %s", resp)
-
-	// store resource data
-	result := resp.Result.(*morpheus.GetIdentitySourceResult)
-	identitySource := result.IdentitySource
-	d.SetId(int64ToString(identitySource.ID))
-	d.Set("name", identitySource.Name)
-	d.Set("description", identitySource.Description)
-	d.Set("ad_server", identitySource.Config.URL)
-	d.Set("domain", identitySource.Config.Domain)
-	if identitySource.Config.UseSSL == "off" {
-		d.Set("use_ssl", false)
-	} else {
-		d.Set("use_ssl", true)
-	}
-	d.Set("binding_username", identitySource.Config.BindingUsername)
-	d.Set("binding_password", identitySource.Config.BindingPasswordHash)
-	d.Set("required_group", identitySource.Config.RequiredGroup)
-	d.Set("search_member_groups", identitySource.Config.SearchMemberGroups)
-	d.Set("enable_role_mapping_permission", identitySource.AllowCustomMappings)
-	d.Set("default_account_role_id", identitySource.DefaultAccountRole.ID)
-
-	var roleMappingPayload []map[string]interface{}
-
-	for _, roleMapping := range identitySource.RoleMappings {
-		roleOutput := make(map[string]interface{})
-		roleOutput["active_directory_group_fqn"] = roleMapping.SourceRoleFqn
-		roleOutput["active_directory_group_name"] = roleMapping.SourceRoleName
-		roleOutput["role_id"] = roleMapping.MappedRole.ID
-		roleOutput["role_name"] = roleMapping.MappedRole.Authority
-		roleMappingPayload = append(roleMappingPayload, roleOutput)
-	}
-	d.Set("role_mapping", roleMappingPayload)
-	return diags
+	rawState["role_mapping"] = upgraded
+	return rawState, nil
 }
 
-func resourceActiveDirectoryIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
-	id := d.Id()
-
-	identitySource := make(map[string]interface{})
-
-	identitySource["name"] = d.Get("name").(string)
-	identitySource["description"] = d.Get("description").(string)
-	identitySource["type"] = "activeDirectory"
-
-	config := make(map[string]interface{})
-	config["url"] = d.Get("ad_server").(string)
-	config["domain"] = d.Get("domain").(string)
-	if d.Get("use_ssl").(bool) {
-		config["useSSL"] = "on"
-	} else {
-		config["useSSL"] = "off"
-	}
-	config["bindingUsername"] = d.Get("binding_username").(string)
-	if d.HasChange("binding_password") {
-		config["bindingPassword"] = d.Get("binding_password").(string)
-	}
-	config["requiredGroup"] = d.Get("required_group").(string)
-	config["searchMemberGroups"] = d.Get("search_member_groups").(bool)
-	config["allowCustomMappings"] = d.Get("enable_role_mapping_permission").(bool)
-
-	identitySource["config"] = config
-
-	defaultAccountRole := make(map[string]interface{})
-	defaultAccountRole["id"] = d.Get("default_account_role_id").(int)
-	identitySource["defaultAccountRole"] = defaultAccountRole
-
-	// Role Mappings
-	if d.Get("role_mapping") != "" {
-		identitySource["roleMappings"] = parseRoleMappings(d.Get("role_mapping").(*schema.Set))
-	}
-
-	req := &morpheus.Request{
-		Body: map[string]interface{}{
-			"userSource": identitySource,
-		},
-	}
-
-	resp, err := client.UpdateIdentitySource(toInt64(id), req)
-	if err != nil {
-		log.Printf("API FAILURE: %s - %s", resp, err)
-		return diag.FromErr(err)
-	}
-	log.Printf("API RESPONSE: %s", resp)
-	result := resp.Result.(*morpheus.UpdateIdentitySourceResult)
-	identitySourceResult := result.IdentitySource
-
-	// Successfully updated resource, now set id
-	// err, it should not have changed though..
-	d.SetId(int64ToString(identitySourceResult.ID))
-	return resourceActiveDirectoryIdentitySourceRead(ctx, d, meta)
+func resourceActiveDirectoryIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createIdentitySource(ctx, d, meta, activeDirectoryIdentitySource)
 }
 
-func resourceActiveDirectoryIdentitySourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	id := d.Id()
-	req := &morpheus.Request{}
-	resp, err := client.DeleteIdentitySource(toInt64(id), req)
-	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
-		} else {
-			log.Printf("API FAILURE: %s - %s", resp, err)
-			return diag.FromErr(err)
-		}
-	}
-	log.Printf("API RESPONSE: %s", resp)
-	d.SetId("")
-	return diags
+func resourceActiveDirectoryIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIdentitySource(ctx, d, meta, activeDirectoryIdentitySource)
 }
 
-func parseRoleMappings(mappings *schema.Set) []map[string]interface{} {
-	var roleMappings []map[string]interface{}
-	// iterate over the array of roleMappings
-	for _, mapping := range mappings.List() {
-		row := make(map[string]interface{})
-		mappedRole := make(map[string]interface{})
-		mappingConfig := mapping.(map[string]interface{})
-		for k, v := range mappingConfig {
-			switch k {
-			case "role_id":
-				mappedRole["id"] = v.(int)
-			case "role_name":
-				mappedRole["authority"] = v.(string)
-			case "active_directory_group_name":
-				row["sourceRoleName"] = v.(string)
-			case "active_directory_group_fqn":
-				row["sourceRoleFqn"] = v.(string)
-			}
-		}
-		row["mappedRole"] = mappedRole
-		roleMappings = append(roleMappings, row)
-	}
-	return roleMappings
+func resourceActiveDirectoryIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateIdentitySource(ctx, d, meta, activeDirectoryIdentitySource)
 }
@@ -144,7 +144,7 @@ func resourceAnsibleIntegration() *schema.Resource {
 }
 
 func resourceAnsibleIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -197,7 +197,7 @@ func resourceAnsibleIntegrationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAnsibleIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -218,7 +218,9 @@ func resourceAnsibleIntegrationRead(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -250,7 +252,7 @@ func resourceAnsibleIntegrationRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceAnsibleIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -300,7 +302,7 @@ func resourceAnsibleIntegrationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAnsibleIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -311,7 +313,8 @@ func resourceAnsibleIntegrationDelete(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
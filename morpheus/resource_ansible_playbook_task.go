@@ -107,7 +107,7 @@ func resourceAnsiblePlaybookTask() *schema.Resource {
 }
 
 func resourceAnsiblePlaybookTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -157,7 +157,7 @@ func resourceAnsiblePlaybookTaskCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceAnsiblePlaybookTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -180,7 +180,9 @@ func resourceAnsiblePlaybookTaskRead(ctx context.Context, d *schema.ResourceData
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -209,7 +211,7 @@ func resourceAnsiblePlaybookTaskRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceAnsiblePlaybookTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -254,7 +256,7 @@ func resourceAnsiblePlaybookTaskUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceAnsiblePlaybookTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -265,7 +267,8 @@ func resourceAnsiblePlaybookTaskDelete(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
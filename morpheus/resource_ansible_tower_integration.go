@@ -69,7 +69,7 @@ func resourceAnsibleTowerIntegration() *schema.Resource {
 }
 
 func resourceAnsibleTowerIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -107,7 +107,7 @@ func resourceAnsibleTowerIntegrationCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceAnsibleTowerIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -128,7 +128,9 @@ func resourceAnsibleTowerIntegrationRead(ctx context.Context, d *schema.Resource
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -150,7 +152,7 @@ func resourceAnsibleTowerIntegrationRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceAnsibleTowerIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -185,7 +187,7 @@ func resourceAnsibleTowerIntegrationUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceAnsibleTowerIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -196,7 +198,8 @@ func resourceAnsibleTowerIntegrationDelete(ctx context.Context, d *schema.Resour
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
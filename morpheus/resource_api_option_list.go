@@ -69,7 +69,7 @@ func resourceApiOptionList() *schema.Resource {
 }
 
 func resourceApiOptionListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -106,7 +106,7 @@ func resourceApiOptionListCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceApiOptionListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -127,7 +127,9 @@ func resourceApiOptionListRead(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -155,7 +157,7 @@ func resourceApiOptionListRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceApiOptionListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -188,7 +190,7 @@ func resourceApiOptionListUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceApiOptionListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -199,7 +201,8 @@ func resourceApiOptionListDelete(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -0,0 +1,431 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceApp() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus app resource to deploy an app blueprint.",
+		CreateContext: resourceAppCreate,
+		ReadContext:   resourceAppRead,
+		UpdateContext: resourceAppUpdate,
+		DeleteContext: resourceAppDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the app",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the app",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the app",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"blueprint_id": {
+				Description: "The ID of the app blueprint to deploy",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the group to deploy the app into",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cloud_id": {
+				Description: "The ID of the default cloud of the app tiers",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"environment": {
+				Description: "The code of the environment to deploy the app into",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"tier": {
+				Description:      "Configuration overrides of an app tier, merged into the tier configuration of the blueprint. The overrides only apply when the app is deployed, they are ignored for an existing app that was deployed without them such as an imported one",
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressAppTierDiff,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the tier in the blueprint",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"config": {
+							Description:      "The JSON configuration of the tier (i.e. - {\"instances\": [{\"config\": {\"resourcePoolId\": 5}}]})",
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: suppressEquivalentJsonDiffs,
+						},
+					},
+				},
+			},
+			"status": {
+				Description: "The status of the app",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"instances": {
+				Description: "The instances of the app",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the instance",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the instance",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tier": {
+							Description: "The app tier of the instance",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// appDetails is an app along with the instances of its tiers, which the
+// SDK App type does not include.
+type appDetails struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Environment string `json:"environment"`
+	Group       struct {
+		ID int64 `json:"id"`
+	} `json:"group"`
+	Blueprint struct {
+		ID int64 `json:"id"`
+	} `json:"blueprint"`
+	AppTiers []struct {
+		Tier struct {
+			Name string `json:"name"`
+		} `json:"tier"`
+		AppInstances []struct {
+			Instance struct {
+				ID     int64  `json:"id"`
+				Name   string `json:"name"`
+				Status string `json:"status"`
+				Cloud  struct {
+					ID int64 `json:"id"`
+				} `json:"cloud"`
+			} `json:"instance"`
+		} `json:"appInstances"`
+	} `json:"appTiers"`
+}
+
+func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	payload := map[string]interface{}{
+		"blueprintId": d.Get("blueprint_id").(int),
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"group": map[string]interface{}{
+			"id": d.Get("group_id").(int),
+		},
+	}
+	if cloudID := d.Get("cloud_id").(int); cloudID != 0 {
+		payload["defaultCloud"] = map[string]interface{}{
+			"id": cloudID,
+		}
+	}
+	if environment := d.Get("environment").(string); environment != "" {
+		payload["environment"] = environment
+	}
+
+	tiers := make(map[string]interface{})
+	for _, item := range d.Get("tier").([]interface{}) {
+		tier := item.(map[string]interface{})
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(tier["config"].(string)), &config); err != nil {
+			return diag.Errorf("error parsing the config of tier %s: %s", tier["name"], err)
+		}
+		tiers[tier["name"].(string)] = config
+	}
+	if len(tiers) > 0 {
+		payload["tiers"] = tiers
+	}
+
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.CreateApp(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateAppResult)
+	if !result.Success || result.App == nil {
+		return diag.Errorf("error creating app: %s", result.Message)
+	}
+	app := result.App
+
+	// Set the id before waiting so that a failed app is tainted
+	d.SetId(int64ToString(app.ID))
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"provisioning"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			details, _, err := getAppDetails(client, app.ID)
+			if err != nil {
+				return nil, "", err
+			}
+			status, err := appProvisionStatus(details)
+			return details, status, err
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionDelay,
+		PollInterval: instanceProvisionPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error creating app: %s", err)
+	}
+
+	// Successfully created resource
+	resourceAppRead(ctx, d, meta)
+	return diags
+}
+
+func resourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	details, resp, err := getAppDetails(client, toInt64(d.Id()))
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(int64ToString(details.ID))
+	d.Set("name", details.Name)
+	d.Set("description", details.Description)
+	d.Set("group_id", details.Group.ID)
+	if details.Blueprint.ID != 0 {
+		d.Set("blueprint_id", details.Blueprint.ID)
+	}
+	d.Set("status", details.Status)
+	if details.Environment != "" {
+		d.Set("environment", details.Environment)
+	}
+
+	var instances []map[string]interface{}
+	clouds := make(map[int64]bool)
+	for _, tier := range details.AppTiers {
+		for _, appInstance := range tier.AppInstances {
+			instances = append(instances, map[string]interface{}{
+				"id":   appInstance.Instance.ID,
+				"name": appInstance.Instance.Name,
+				"tier": tier.Tier.Name,
+			})
+			if appInstance.Instance.Cloud.ID != 0 {
+				clouds[appInstance.Instance.Cloud.ID] = true
+			}
+		}
+	}
+	d.Set("instances", instances)
+
+	// the API does not return the default cloud, it is the cloud of the
+	// instances unless tiers override it
+	if len(clouds) == 1 {
+		for cloudID := range clouds {
+			d.Set("cloud_id", cloudID)
+		}
+	}
+	return diags
+}
+
+func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"app": map[string]interface{}{
+				"name":        d.Get("name").(string),
+				"description": d.Get("description").(string),
+			},
+		},
+	}
+	resp, err := client.UpdateApp(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateAppResult)
+	app := result.App
+
+	// Successfully updated resource, now set id
+	d.SetId(int64ToString(app.ID))
+	return resourceAppRead(ctx, d, meta)
+}
+
+func resourceAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := toInt64(d.Id())
+	req := &morpheus.Request{
+		QueryParams: map[string]string{
+			"removeInstances": "on",
+		},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.DeleteApp(id, req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// The instances of the app are removed in the background
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"removing"},
+		Target:  []string{"removed"},
+		Refresh: func() (interface{}, string, error) {
+			details, resp, err := getAppDetails(client, id)
+			if err != nil {
+				if resp != nil && resp.StatusCode == 404 {
+					return "", "removed", nil
+				}
+				return nil, "", err
+			}
+			return details, "removing", nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		MinTimeout:   instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error deleting app: %s", err)
+	}
+	d.SetId("")
+	return diags
+}
+
+// suppressAppTierDiff ignores the tier overrides of an existing app when
+// there are none in the state. The overrides only apply when the app is
+// deployed and the API does not return them, so they are unknown for
+// imported apps.
+func suppressAppTierDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, _ := d.GetChange("tier")
+	return d.Id() != "" && len(o.([]interface{})) == 0
+}
+
+// getAppDetails fetches an app along with the instances of its tiers.
+func getAppDetails(client *morpheus.Client, id int64) (*appDetails, *morpheus.Response, error) {
+	resp, err := client.GetApp(id, &morpheus.Request{})
+	if err != nil {
+		if resp == nil || resp.StatusCode != 404 {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+		}
+		return nil, resp, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	var result struct {
+		App appDetails `json:"app"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, resp, err
+	}
+	return &result.App, resp, nil
+}
+
+// appProvisionStatus returns running once the app and all of its instances
+// are running, and fails as soon as any of them fails to provision.
+func appProvisionStatus(app *appDetails) (string, error) {
+	var failed []string
+	running := app.Status == "running"
+	for _, tier := range app.AppTiers {
+		for _, appInstance := range tier.AppInstances {
+			instance := appInstance.Instance
+			for _, status := range instanceFailureStates {
+				if instance.Status == status {
+					failed = append(failed, fmt.Sprintf("%s/%s is %s", tier.Tier.Name, instance.Name, instance.Status))
+				}
+			}
+			if instance.Status != "running" {
+				running = false
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return app.Status, fmt.Errorf("app %s failed to provision: %s", app.Name, strings.Join(failed, ", "))
+	}
+	for _, status := range instanceFailureStates {
+		if app.Status == status {
+			return app.Status, fmt.Errorf("app %s is %s", app.Name, app.Status)
+		}
+	}
+	if running {
+		return "running", nil
+	}
+	return "provisioning", nil
+}
@@ -0,0 +1,167 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockApps makes the mock server deploy apps the way the API does,
+// creating an instance in the given status for each tier of the request.
+func testMockApps(s *mockServer, status string) {
+	s.setUnwrapped("/api/apps")
+	s.addCreateHook("/api/apps", func(record map[string]interface{}, body map[string]interface{}) {
+		record["blueprint"] = map[string]interface{}{"id": body["blueprintId"]}
+		cloud, _ := body["defaultCloud"].(map[string]interface{})
+		tiers, _ := body["tiers"].(map[string]interface{})
+		if len(tiers) == 0 {
+			tiers = map[string]interface{}{"App": nil}
+		}
+		var names []string
+		for name := range tiers {
+			names = append(names, name)
+		}
+		var appTiers []interface{}
+		for _, c := range s.collections {
+			if c.path != "/api/instances" {
+				continue
+			}
+			for _, name := range names {
+				instanceName := fmt.Sprintf("%s-%s", record["name"], strings.ToLower(name))
+				id := s.insert(c, map[string]interface{}{"name": instanceName, "status": status, "cloud": cloud})
+				appTiers = append(appTiers, map[string]interface{}{
+					"tier": map[string]interface{}{"name": name},
+					"appInstances": []interface{}{
+						map[string]interface{}{"instance": map[string]interface{}{"id": id, "name": instanceName, "status": status, "cloud": cloud}},
+					},
+				})
+			}
+		}
+		record["appTiers"] = appTiers
+	})
+}
+
+func testAppConfig(s *mockServer, name string) map[string]interface{} {
+	group := s.seed("/api/groups", map[string]interface{}{"name": "All Clouds"})
+	return map[string]interface{}{
+		"name":         name,
+		"description":  "deployed by terraform",
+		"blueprint_id": 4,
+		"group_id":     int(group),
+		"cloud_id":     2,
+		"environment":  "dev",
+		"tier": []interface{}{
+			map[string]interface{}{"name": "Web", "config": `{"instances": [{"config": {"resourcePoolId": 5}}]}`},
+		},
+	}
+}
+
+func TestResourceMorpheusApp_lifecycle(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockApps(s, "running")
+	create := testAppConfig(s, "tfapp")
+	update := make(map[string]interface{})
+	for k, v := range create {
+		update[k] = v
+	}
+	update["description"] = "updated by terraform"
+	testResourceLifecycle(t, s, "morpheus_app", "/api/apps", create, update)
+}
+
+func TestResourceMorpheusApp_payload(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockApps(s, "running")
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_app", nil, testAppConfig(s, "tfapp"))
+	body := s.requestsFor("POST", "/api/apps")[0].Body
+	if body["blueprintId"] != float64(4) || body["environment"] != "dev" {
+		t.Fatalf("unexpected app payload: %v", body)
+	}
+	if body["defaultCloud"].(map[string]interface{})["id"] != float64(2) {
+		t.Fatalf("expected default cloud 2, got %v", body["defaultCloud"])
+	}
+	web := body["tiers"].(map[string]interface{})["Web"].(map[string]interface{})
+	instances := web["instances"].([]interface{})
+	if instances[0].(map[string]interface{})["config"].(map[string]interface{})["resourcePoolId"] != float64(5) {
+		t.Fatalf("expected the tier config override to be sent, got %v", web)
+	}
+
+	if state.Attributes["instances.#"] != "1" || state.Attributes["instances.0.name"] != "tfapp-web" || state.Attributes["instances.0.tier"] != "Web" {
+		t.Fatalf("unexpected instances: %v", state.Attributes)
+	}
+	if state.Attributes["status"] != "running" {
+		t.Fatalf("expected the app to be running, got %q", state.Attributes["status"])
+	}
+
+	testResourceDestroy(t, meta, "morpheus_app", state)
+	if query := s.requestsFor("DELETE", "/api/apps/"+state.ID)[0].Query; !strings.Contains(query, "removeInstances=on") {
+		t.Fatalf("expected the instances to be removed with the app, got %q", query)
+	}
+}
+
+func TestResourceMorpheusApp_import(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockApps(s, "running")
+	meta := testProviderMeta(t, s)
+	config := testAppConfig(s, "tfapp")
+
+	state := testResourceApply(t, meta, "morpheus_app", nil, config)
+	imported := testResourceImport(t, meta, "morpheus_app", state.ID)
+	if imported.Attributes["cloud_id"] != "2" || imported.Attributes["environment"] != "dev" {
+		t.Fatalf("expected the cloud and environment to be read back, got %v", imported.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_app", imported, config)
+
+	// changing the tier overrides of an app deployed with them still replaces it
+	config["tier"] = []interface{}{
+		map[string]interface{}{"name": "Web", "config": `{"instances": [{"config": {"resourcePoolId": 6}}]}`},
+	}
+	diff, err := testResource(t, "morpheus_app").Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning morpheus_app: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatal("expected changing the tier overrides to replace the app")
+	}
+}
+
+func TestResourceMorpheusApp_failed(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockApps(s, "failed")
+	meta := testProviderMeta(t, s)
+
+	err := testResourceApplyError(t, meta, "morpheus_app", nil, testAppConfig(s, "tfapp"))
+	if !strings.Contains(err, "Web/tfapp-web is failed") {
+		t.Fatalf("expected the failed tier to be reported, got %q", err)
+	}
+}
+
+func TestAppProvisionStatus(t *testing.T) {
+	app := &appDetails{Name: "tfapp", Status: "running"}
+	if status, err := appProvisionStatus(app); err != nil || status != "running" {
+		t.Fatalf("expected running, got %q (%v)", status, err)
+	}
+	app.Status = "provisioning"
+	if status, err := appProvisionStatus(app); err != nil || status != "provisioning" {
+		t.Fatalf("expected provisioning, got %q (%v)", status, err)
+	}
+	app.Status = "cancelled"
+	if _, err := appProvisionStatus(app); err == nil {
+		t.Fatal("expected a cancelled app to fail")
+	}
+}
+
+func TestResourceMorpheusApp_disappears(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockApps(s, "running")
+	testResourceDisappears(t, s, "morpheus_app", "/api/apps", testAppConfig(s, "tfapp"))
+}
@@ -64,7 +64,7 @@ func resourceAWSCloud() *schema.Resource {
 				Default:      "private",
 			},
 			"tenant_id": {
-				Description: "The id of the morpheus tenant the cloud is assigned to",
+				Description: "The id of the morpheus tenant the cloud is assigned to, defaults to the tenant the provider is scoped to",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
@@ -182,7 +182,7 @@ func resourceAWSCloud() *schema.Resource {
 }
 
 func resourceAWSCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -193,10 +193,15 @@ func resourceAWSCloudCreate(ctx context.Context, d *schema.ResourceData, meta in
 	cloud["location"] = d.Get("location").(string)
 	cloud["visibility"] = d.Get("visibility").(string)
 
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
 	account := make(map[string]interface{})
-	account["id"] = d.Get("tenant_id").(string)
+	account["id"] = tenantID
 	cloud["account"] = account
-	cloud["accountId"] = d.Get("tenant_id").(string)
+	cloud["accountId"] = tenantID
 
 	cloud["enabled"] = d.Get("enabled").(bool)
 	cloud["autoRecoverPowerState"] = d.Get("automatically_power_on_vms").(bool)
@@ -295,7 +300,7 @@ func resourceAWSCloudCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAWSCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -316,6 +321,9 @@ func resourceAWSCloudRead(ctx context.Context, d *schema.ResourceData, meta inte
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -366,7 +374,7 @@ func resourceAWSCloudRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceAWSCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	cloud := make(map[string]interface{})
 	cloud["name"] = d.Get("name").(string)
@@ -374,10 +382,15 @@ func resourceAWSCloudUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	cloud["location"] = d.Get("location").(string)
 	cloud["visibility"] = d.Get("visibility").(string)
 
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
 	account := make(map[string]interface{})
-	account["id"] = d.Get("tenant_id").(string)
+	account["id"] = tenantID
 	cloud["account"] = account
-	cloud["accountId"] = d.Get("tenant_id").(string)
+	cloud["accountId"] = tenantID
 
 	cloud["enabled"] = d.Get("enabled").(bool)
 	cloud["autoRecoverPowerState"] = d.Get("automatically_power_on_vms").(bool)
@@ -449,7 +462,7 @@ func resourceAWSCloudUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAWSCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -460,7 +473,8 @@ func resourceAWSCloudDelete(ctx context.Context, d *schema.ResourceData, meta in
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
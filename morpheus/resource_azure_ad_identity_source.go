@@ -0,0 +1,106 @@
+package morpheus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var azureADIdentitySource = identitySourceType{
+	code:        "azureAD",
+	title:       "Azure AD",
+	description: "Azure AD",
+	groupPrefix: "group",
+	groupName:   "The name of the Azure AD group to map to",
+	groupFqn:    "The object ID of the Azure AD group to map to",
+	settings: []identitySourceSetting{
+		{attribute: "login_url", field: "loginUrl"},
+		{attribute: "logout_url", field: "logoutUrl"},
+		{attribute: "application_id", field: "clientId"},
+		{attribute: "application_secret", field: "clientSecret", secret: true},
+		{attribute: "required_group_id", field: "requiredGroupId"},
+		{attribute: "signature_mode", field: "SAMLSignatureMode"},
+		{attribute: "public_key", field: "publicKey"},
+		{attribute: "private_key", field: "privateKey", secret: true},
+	},
+}
+
+func resourceAzureADIdentitySource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an Azure AD identity source resource",
+		CreateContext: resourceAzureADIdentitySourceCreate,
+		ReadContext:   resourceAzureADIdentitySourceRead,
+		UpdateContext: resourceAzureADIdentitySourceUpdate,
+		DeleteContext: resourceIdentitySourceDelete,
+		CustomizeDiff: customizeIdentitySourceRoleMappingDiff(azureADIdentitySource.groupPrefix),
+
+		Schema: identitySourceSchema(azureADIdentitySource, map[string]*schema.Schema{
+			"login_url": {
+				Type:        schema.TypeString,
+				Description: "The SAML login URL of the Azure AD enterprise application",
+				Required:    true,
+			},
+			"logout_url": {
+				Type:        schema.TypeString,
+				Description: "The URL users are redirected to when they log out of Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Description: "The application (client) ID of the Azure AD app registration used to look up groups",
+				Required:    true,
+			},
+			"application_secret": {
+				Type:             schema.TypeString,
+				Description:      "The client secret of the Azure AD app registration",
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"required_group_id": {
+				Type:        schema.TypeString,
+				Description: "The object ID of the Azure AD group users must be in to access Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"signature_mode": {
+				Type:         schema.TypeString,
+				Description:  "The method used to sign SAML requests (NoSigning, SelfSigned or CustomRSA)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"NoSigning", "SelfSigned", "CustomRSA"}, false),
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Description: "The certificate of the Azure AD enterprise application used to validate SAML responses",
+				Optional:    true,
+				Computed:    true,
+			},
+			"private_key": {
+				Type:             schema.TypeString,
+				Description:      "The private key used to sign SAML requests when the signature mode is CustomRSA",
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceAzureADIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createIdentitySource(ctx, d, meta, azureADIdentitySource)
+}
+
+func resourceAzureADIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIdentitySource(ctx, d, meta, azureADIdentitySource)
+}
+
+func resourceAzureADIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateIdentitySource(ctx, d, meta, azureADIdentitySource)
+}
@@ -0,0 +1,403 @@
+package morpheus
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAzureCloud() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus Azure cloud resource.",
+		CreateContext: resourceAzureCloudCreate,
+		ReadContext:   resourceAzureCloudRead,
+		UpdateContext: resourceAzureCloudUpdate,
+		DeleteContext: resourceAzureCloudDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the cloud",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the cloud integration",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"code": {
+				Description: "Optional code for use with policies",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"location": {
+				Description: "Optional location for the cloud",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"visibility": {
+				Description:  "Determines whether the cloud is visible in sub-tenants or not",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public", ""}, false),
+				Default:      "private",
+			},
+			"tenant_id": {
+				Description: "The id of the morpheus tenant the cloud is assigned to, defaults to the tenant the provider is scoped to",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "Determines whether the cloud is active or not",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"automatically_power_on_vms": {
+				Description: "Determines whether to automatically power on cloud virtual machines",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"azure_cloud_type": {
+				Type:         schema.TypeString,
+				Description:  "The Azure cloud environment (global, usgov, german, china)",
+				ValidateFunc: validation.StringInSlice([]string{"global", "usgov", "german", "china"}, false),
+				Optional:     true,
+				Default:      "global",
+			},
+			"subscription_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the Azure subscription",
+				Required:    true,
+			},
+			"azure_tenant_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the Azure Active Directory tenant the subscription belongs to",
+				Required:    true,
+			},
+			"credential_id": {
+				Description:   "The ID of the credential store entry used for authentication",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"client_id", "client_secret"},
+			},
+			"client_id": {
+				Type:         schema.TypeString,
+				Description:  "The client ID of the Azure application registration used for authentication",
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"client_secret"},
+			},
+			"client_secret": {
+				Type:             schema.TypeString,
+				Description:      "The client secret of the Azure application registration used for authentication",
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+				RequiredWith:     []string{"client_id"},
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Description: "The Azure region associated with the cloud integration (i.e. - eastus), all regions are used when not set",
+				Optional:    true,
+			},
+			"resource_group": {
+				Type:        schema.TypeString,
+				Description: "The name of a resource group to scope the cloud to (all or the resource group name)",
+				Optional:    true,
+				Default:     "all",
+			},
+			"inventory": {
+				Type:         schema.TypeString,
+				Description:  "Whether to import existing virtual machines (off, basic, full)",
+				ValidateFunc: validation.StringInSlice([]string{"off", "basic", "full", ""}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+			"appliance_url": {
+				Type:        schema.TypeString,
+				Description: "The URL used by workloads provisioned in the cloud for interacting with the Morpheus server",
+				Optional:    true,
+				Computed:    true,
+			},
+			"time_zone": {
+				Type:        schema.TypeString,
+				Description: "The time zone for the cloud",
+				Optional:    true,
+				Computed:    true,
+			},
+			"datacenter_id": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary id used to reference the datacenter for the cloud",
+				Optional:    true,
+				Computed:    true,
+			},
+			"guidance": {
+				Type:         schema.TypeString,
+				Description:  "Whether to enable guidance recommendations on the cloud (manual, off)",
+				ValidateFunc: validation.StringInSlice([]string{"manual", "off"}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+			"costing": {
+				Type:         schema.TypeString,
+				Description:  "Whether to enable costing on the cloud (off, costing, full)",
+				ValidateFunc: validation.StringInSlice([]string{"off", "costing", "full"}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+			"agent_install_mode": {
+				Type:         schema.TypeString,
+				Description:  "The method used to install the Morpheus agent on virtual machines provisioned in the cloud (ssh, cloudInit)",
+				ValidateFunc: validation.StringInSlice([]string{"ssh", "cloudInit", ""}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceAzureCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
+
+	payload := map[string]interface{}{
+		"zone": azureCloudPayload(d, tenantID),
+	}
+
+	req := &morpheus.Request{Body: payload}
+
+	resp, err := client.CreateCloud(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateCloudResult)
+	cloudOutput := result.Cloud
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(cloudOutput.ID))
+
+	if err := waitForCloudSync(ctx, client, cloudOutput.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error creating cloud: %s", err)
+	}
+
+	resourceAzureCloudRead(ctx, d, meta)
+	return diags
+}
+
+func resourceAzureCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	name := d.Get("name").(string)
+
+	// lookup by name if we do not have an id yet
+	var resp *morpheus.Response
+	var err error
+	if id == "" && name != "" {
+		resp, err = client.FindCloudByName(name)
+	} else if id != "" {
+		resp, err = client.GetCloud(toInt64(id), &morpheus.Request{})
+	} else {
+		return diag.Errorf("Cloud cannot be read without name or id")
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetCloudResult)
+	cloud := result.Cloud
+	if cloud == nil {
+		d.SetId("")
+		return diags
+	}
+	config, err := cloudRawConfig(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(int64ToString(cloud.ID))
+	d.Set("name", cloud.Name)
+	d.Set("code", cloud.Code)
+	d.Set("location", cloud.Location)
+	d.Set("visibility", cloud.Visibility)
+	d.Set("tenant_id", strconv.Itoa(int(cloud.AccountID)))
+	d.Set("enabled", cloud.Enabled)
+	d.Set("automatically_power_on_vms", cloud.AutoRecoverPowerState)
+	if cloud.Config.CloudType != "" {
+		d.Set("azure_cloud_type", cloud.Config.CloudType)
+	}
+	d.Set("subscription_id", cloud.Config.SubscriberID)
+	d.Set("azure_tenant_id", cloud.Config.TenantID)
+	d.Set("credential_id", cloud.Credential.ID)
+	d.Set("client_id", cloud.Config.ClientID)
+	d.Set("client_secret", cloud.Config.ClientSecretHash)
+	d.Set("region", cloudConfigString(config, "region"))
+	if cloud.Config.ResourceGroup == "" {
+		d.Set("resource_group", "all")
+	} else {
+		d.Set("resource_group", cloud.Config.ResourceGroup)
+	}
+	d.Set("inventory", cloud.InventoryLevel)
+	d.Set("appliance_url", cloud.Config.ApplianceUrl)
+	d.Set("time_zone", cloud.TimeZone)
+	d.Set("datacenter_id", cloud.Config.DatacenterName)
+	d.Set("guidance", cloud.GuidanceMode)
+	d.Set("costing", cloud.CostingMode)
+	d.Set("agent_install_mode", cloud.AgentMode)
+	return diags
+}
+
+func resourceAzureCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	payload := map[string]interface{}{
+		"zone": azureCloudPayload(d, d.Get("tenant_id").(string)),
+	}
+
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.UpdateCloud(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateCloudResult)
+	cloudOutput := result.Cloud
+	// Successfully updated resource, now set id
+	d.SetId(int64ToString(cloudOutput.ID))
+	return resourceAzureCloudRead(ctx, d, meta)
+}
+
+func resourceAzureCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteCloud(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// azureCloudPayload builds the zone payload shared by create and update.
+func azureCloudPayload(d *schema.ResourceData, tenantID string) map[string]interface{} {
+	cloud := make(map[string]interface{})
+	cloud["name"] = d.Get("name").(string)
+	cloud["code"] = d.Get("code").(string)
+	cloud["location"] = d.Get("location").(string)
+	cloud["visibility"] = d.Get("visibility").(string)
+
+	account := make(map[string]interface{})
+	account["id"] = tenantID
+	cloud["account"] = account
+	cloud["accountId"] = tenantID
+
+	cloud["enabled"] = d.Get("enabled").(bool)
+	cloud["autoRecoverPowerState"] = d.Get("automatically_power_on_vms").(bool)
+
+	config := make(map[string]interface{})
+	config["cloudType"] = d.Get("azure_cloud_type").(string)
+	config["subscriberId"] = d.Get("subscription_id").(string)
+	config["tenantId"] = d.Get("azure_tenant_id").(string)
+	config["region"] = d.Get("region").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "client-id-secret"
+		credential["id"] = d.Get("credential_id").(int)
+		cloud["credential"] = credential
+	} else {
+		credential := make(map[string]interface{})
+		credential["type"] = "local"
+		cloud["credential"] = credential
+		config["clientId"] = d.Get("client_id").(string)
+		// the state only holds the hash of the secret
+		if d.IsNewResource() || d.HasChange("client_secret") {
+			config["clientSecret"] = d.Get("client_secret").(string)
+		}
+	}
+
+	// Select all resource groups by passing an
+	// empty string to the API
+	if d.Get("resource_group").(string) == "all" {
+		config["resourceGroup"] = ""
+	} else {
+		config["resourceGroup"] = d.Get("resource_group").(string)
+	}
+
+	cloud["inventoryLevel"] = d.Get("inventory").(string)
+	if d.Get("inventory").(string) != "" && d.Get("inventory").(string) != "off" {
+		config["importExisting"] = "on"
+	} else {
+		config["importExisting"] = "off"
+	}
+
+	config["applianceUrl"] = d.Get("appliance_url")
+	cloud["timezone"] = d.Get("time_zone").(string)
+	config["datacenterName"] = d.Get("datacenter_id")
+	cloud["guidanceMode"] = d.Get("guidance").(string)
+	cloud["costingMode"] = d.Get("costing").(string)
+	cloud["agentMode"] = d.Get("agent_install_mode").(string)
+
+	cloud["config"] = config
+
+	cloudType := make(map[string]interface{})
+	cloudType["code"] = "azure"
+	cloud["zoneType"] = cloudType
+
+	return cloud
+}
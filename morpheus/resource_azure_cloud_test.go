@@ -0,0 +1,105 @@
+package morpheus
+
+import (
+	"testing"
+)
+
+func testAzureCloudConfig(location string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               "tfazure",
+		"code":               "tfazure",
+		"location":           location,
+		"subscription_id":    "00000000-0000-0000-0000-000000000001",
+		"azure_tenant_id":    "00000000-0000-0000-0000-000000000002",
+		"client_id":          "00000000-0000-0000-0000-000000000003",
+		"client_secret":      "Secret123",
+		"region":             "eastus",
+		"resource_group":     "morpheus-rg",
+		"inventory":          "full",
+		"costing":            "costing",
+		"agent_install_mode": "cloudInit",
+	}
+}
+
+func TestResourceMorpheusAzureCloud_lifecycle(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	testResourceLifecycle(t, s, "morpheus_azure_cloud", "/api/zones", testAzureCloudConfig("denver"), testAzureCloudConfig("boulder"))
+}
+
+func TestResourceMorpheusAzureCloud_payload(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_azure_cloud", nil, testAzureCloudConfig("denver"))
+	requests := s.requestsFor("POST", "/api/zones")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	zone := requests[0].Body["zone"].(map[string]interface{})
+	if zone["zoneType"].(map[string]interface{})["code"] != "azure" {
+		t.Fatalf("expected zoneType azure, got %v", zone["zoneType"])
+	}
+	if zone["inventoryLevel"] != "full" || zone["costingMode"] != "costing" || zone["agentMode"] != "cloudInit" {
+		t.Fatalf("unexpected cloud settings: %v", zone)
+	}
+	config := zone["config"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"cloudType":      "global",
+		"subscriberId":   "00000000-0000-0000-0000-000000000001",
+		"tenantId":       "00000000-0000-0000-0000-000000000002",
+		"clientId":       "00000000-0000-0000-0000-000000000003",
+		"clientSecret":   "Secret123",
+		"region":         "eastus",
+		"resourceGroup":  "morpheus-rg",
+		"importExisting": "on",
+	}
+	for k, v := range expected {
+		if config[k] != v {
+			t.Fatalf("expected config %s to be %v, got %v", k, v, config[k])
+		}
+	}
+
+	// the secret is only sent again when it changes
+	update := testAzureCloudConfig("boulder")
+	testResourceApply(t, meta, "morpheus_azure_cloud", state, update)
+	requests = s.requestsFor("PUT", "/api/zones/"+state.ID)
+	config = requests[0].Body["zone"].(map[string]interface{})["config"].(map[string]interface{})
+	if _, ok := config["clientSecret"]; ok {
+		t.Fatalf("expected unchanged client secret not to be sent, got %v", config["clientSecret"])
+	}
+}
+
+func TestResourceMorpheusAzureCloud_credential(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+
+	config := testAzureCloudConfig("denver")
+	delete(config, "client_id")
+	delete(config, "client_secret")
+	config["credential_id"] = 5
+	testResourceApply(t, meta, "morpheus_azure_cloud", nil, config)
+
+	zone := s.requestsFor("POST", "/api/zones")[0].Body["zone"].(map[string]interface{})
+	credential := zone["credential"].(map[string]interface{})
+	if credential["type"] != "client-id-secret" || credential["id"] != float64(5) {
+		t.Fatalf("expected credential store entry 5, got %v", credential)
+	}
+	if _, ok := zone["config"].(map[string]interface{})["clientSecret"]; ok {
+		t.Fatal("expected no client secret to be sent with a credential store entry")
+	}
+}
+
+func TestResourceMorpheusAzureCloud_syncFailure(t *testing.T) {
+	testCloudSyncFailure(t, "morpheus_azure_cloud", testAzureCloudConfig("denver"))
+}
+
+func TestResourceMorpheusAzureCloud_disappears(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_azure_cloud", "/api/zones", testAzureCloudConfig("denver"))
+}
@@ -112,7 +112,7 @@ func resourceBudgetPolicy() *schema.Resource {
 }
 
 func resourceBudgetPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -184,7 +184,7 @@ func resourceBudgetPolicyCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceBudgetPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -205,7 +205,9 @@ func resourceBudgetPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -255,7 +257,7 @@ func resourceBudgetPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceBudgetPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -325,7 +327,7 @@ func resourceBudgetPolicyUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceBudgetPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -336,7 +338,8 @@ func resourceBudgetPolicyDelete(ctx context.Context, d *schema.ResourceData, met
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -108,7 +108,7 @@ func resourceCheckboxOptionType() *schema.Resource {
 }
 
 func resourceCheckboxOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -164,7 +164,7 @@ func resourceCheckboxOptionTypeCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCheckboxOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -187,7 +187,9 @@ func resourceCheckboxOptionTypeRead(ctx context.Context, d *schema.ResourceData,
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -225,7 +227,7 @@ func resourceCheckboxOptionTypeRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCheckboxOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -276,7 +278,7 @@ func resourceCheckboxOptionTypeUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCheckboxOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -287,7 +289,8 @@ func resourceCheckboxOptionTypeDelete(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
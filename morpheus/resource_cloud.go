@@ -2,21 +2,33 @@ package morpheus
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCloud() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Provides a Morpheus cloud resource.",
+		Description:   "Provides a generic Morpheus cloud resource for cloud types that do not have a dedicated resource.",
 		CreateContext: resourceCloudCreate,
 		ReadContext:   resourceCloudRead,
 		UpdateContext: resourceCloudUpdate,
 		DeleteContext: resourceCloudDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of the cloud",
@@ -29,9 +41,10 @@ func resourceCloud() *schema.Resource {
 				Required:    true,
 			},
 			"type": {
-				Description: "The cloud type code",
+				Description: "The cloud type code (i.e. - azure, openstack, nutanix)",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"code": {
 				Description: "Optional code for use with policies",
@@ -55,6 +68,12 @@ func resourceCloud() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"private", "public", ""}, false),
 				Default:      "private",
 			},
+			"tenant_id": {
+				Description: "The id of the morpheus tenant the cloud is assigned to, defaults to the tenant the provider is scoped to",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
 			"enabled": {
 				Description: "Determines whether the cloud is active or not",
 				Type:        schema.TypeBool,
@@ -62,114 +81,48 @@ func resourceCloud() *schema.Resource {
 				Default:     true,
 			},
 			"config": {
-				Description: "The cloud configuration settings",
+				Description: "The cloud type specific configuration settings sent to the API as is. Credential settings such as passwords and secrets are not returned by the API, use sensitive_config for those",
 				Type:        schema.TypeMap,
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
-
-			// 			"api_url": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "username": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "password": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "datacenter": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "cluster": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "resource_pool": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// },
-			// "rpc_mode": {
-			// 	Type:     schema.TypeString,
-			// 	Required: false,
-			// 	// Default: "guestexec",
-			// },
-			// "hide_host_selection": {
-			// 	Type:     schema.TypeBool,
-			// 	Required: false,
-			// 	// Default: false,
-			// },
-			// "enable_vnc": {
-			// 	Type:     schema.TypeBool,
-			// 	Required: false,
-			// 	// Default: false,
-			// },
-			// "import_existing": {
-			// 	Type:     schema.TypeBool,
-			// 	Required: false,
-			// 	// Default: false,
-			// },
-
-			// a TON more to add...
-
-			"tenants": {
-				Description: "",
-				Type:        schema.TypeList,
+			"sensitive_config": {
+				Description: "The cloud type specific credential settings, merged with config when sent to the API",
+				Type:        schema.TypeMap,
 				Optional:    true,
+				Sensitive:   true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
-			"groups": {
-				Description: "The group the cloud is assigned to",
-				Type:        schema.TypeList,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+			"status": {
+				Description: "The status of the cloud",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceCloudImport,
 		},
 	}
 }
 
 func resourceCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	name := d.Get("name").(string)
-	code := d.Get("code").(string)
-	location := d.Get("location").(string)
-	visibility := d.Get("visibility").(string)
-
+	cloud := cloudPayload(d, meta)
 	// api expects zoneType.code, silly
-	cloudTypeCode := d.Get("type").(string)
-
-	// config is a big map of who knows what
-	var config map[string]interface{}
-	if d.Get("config") != nil {
-		config = d.Get("config").(map[string]interface{})
+	cloud["zoneType"] = map[string]interface{}{
+		"code": d.Get("type").(string),
 	}
 
-	payload := map[string]interface{}{
-		"zone": map[string]interface{}{
-			"name":     name,
-			"code":     code,
-			"location": location,
-			"zoneType": map[string]interface{}{
-				"code": cloudTypeCode,
-			},
-			"config":     config,
-			"visibility": visibility,
-			// "groups": groups,
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"zone": cloud,
 		},
 	}
 
-	req := &morpheus.Request{Body: payload}
-
 	resp, err := client.CreateCloud(req)
 	if err != nil {
 		log.Printf("API FAILURE: %s - %s", resp, err)
@@ -177,15 +130,20 @@ func resourceCloudCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.CreateCloudResult)
-	cloud := result.Cloud
+	cloudOutput := result.Cloud
 	// Successfully created resource, now set id
-	d.SetId(int64ToString(cloud.ID))
+	d.SetId(int64ToString(cloudOutput.ID))
+
+	if err := waitForCloudSync(ctx, client, cloudOutput.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error creating cloud: %s", err)
+	}
+
 	resourceCloudRead(ctx, d, meta)
 	return diags
 }
 
 func resourceCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -200,7 +158,6 @@ func resourceCloudRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		resp, err = client.FindCloudByName(name)
 	} else if id != "" {
 		resp, err = client.GetCloud(toInt64(id), &morpheus.Request{})
-		// todo: ignore 404 errors...
 	} else {
 		return diag.Errorf("Cloud cannot be read without name or id")
 	}
@@ -208,7 +165,9 @@ func resourceCloudRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -225,32 +184,47 @@ func resourceCloudRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	d.SetId(int64ToString(cloud.ID))
 	d.Set("name", cloud.Name)
+	d.Set("type", cloud.CloudType.Code)
 	d.Set("code", cloud.Code)
 	d.Set("location", cloud.Location)
 	d.Set("visibility", cloud.Visibility)
 	d.Set("enabled", cloud.Enabled)
-	// d.Set("groups", cloud.Groups)
-	// todo: more fields
+	d.Set("tenant_id", strconv.Itoa(int(cloud.AccountID)))
+	d.Set("status", cloud.Status)
+
+	zone, err := cloudRawZone(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("description", zone.Description)
+	d.Set("config", cloudConfig(d, zone.Config))
 
 	return diags
 }
 
+func resourceCloudImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*providerMeta).client
+	resp, err := client.GetCloud(toInt64(d.Id()), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	zone, err := cloudRawZone(resp)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("config", cloudImportConfig(zone.Config))
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
-	name := d.Get("name").(string)
-	code := d.Get("code").(string)
-	location := d.Get("location").(string)
-	// clouds := d.Get("clouds").([]interface{})
 
 	req := &morpheus.Request{
 		Body: map[string]interface{}{
-			"zone": map[string]interface{}{
-				"name":     name,
-				"code":     code,
-				"location": location,
-				// "clouds": clouds,
-			},
+			"zone": cloudPayload(d, meta),
 		},
 	}
 	resp, err := client.UpdateCloud(toInt64(id), req)
@@ -267,7 +241,7 @@ func resourceCloudUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -278,7 +252,8 @@ func resourceCloudDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -289,3 +264,166 @@ func resourceCloudDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	d.SetId("")
 	return diags
 }
+
+// The initial sync of a new cloud takes a while, these control how long
+// to wait before and between checking on it.
+var (
+	cloudSyncDelay        = 3 * time.Minute
+	cloudSyncPollInterval = 1 * time.Minute
+)
+
+// waitForCloudSync waits for a new cloud to finish its initial sync.
+func waitForCloudSync(ctx context.Context, client *morpheus.Client, id int64, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"initializing", "syncing"},
+		Target:  []string{"ok"},
+		Refresh: func() (interface{}, string, error) {
+			cloudDetails, err := client.GetCloud(id, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := cloudDetails.Result.(*morpheus.GetCloudResult)
+			cloudStatus := result.Cloud
+			return result, cloudStatus.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   cloudSyncPollInterval,
+		Delay:        cloudSyncDelay,
+		PollInterval: cloudSyncPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// rawZone holds the settings of a cloud the sdk does not know about.
+type rawZone struct {
+	Description string                 `json:"description"`
+	Config      map[string]interface{} `json:"config"`
+}
+
+// cloudRawZone parses the cloud in the API response. The config struct of
+// the sdk only knows the settings of a few cloud types.
+func cloudRawZone(resp *morpheus.Response) (*rawZone, error) {
+	var payload struct {
+		Zone rawZone `json:"zone"`
+	}
+	if err := json.Unmarshal(resp.Body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Zone.Config == nil {
+		payload.Zone.Config = map[string]interface{}{}
+	}
+	return &payload.Zone, nil
+}
+
+// cloudRawConfig returns the config of the cloud in the API response.
+func cloudRawConfig(resp *morpheus.Response) (map[string]interface{}, error) {
+	zone, err := cloudRawZone(resp)
+	if err != nil {
+		return nil, err
+	}
+	return zone.Config, nil
+}
+
+// cloudConfigString returns the cloud setting with the given key as a string.
+func cloudConfigString(config map[string]interface{}, key string) string {
+	switch v := config[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// cloudPayload builds the zone payload shared by create and update.
+func cloudPayload(d *schema.ResourceData, meta interface{}) map[string]interface{} {
+	// config is a big map of who knows what
+	config := make(map[string]interface{})
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		config[k] = v
+	}
+	for k, v := range d.Get("sensitive_config").(map[string]interface{}) {
+		config[k] = v
+	}
+
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
+
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"code":        d.Get("code").(string),
+		"location":    d.Get("location").(string),
+		"description": d.Get("description").(string),
+		"visibility":  d.Get("visibility").(string),
+		"enabled":     d.Get("enabled").(bool),
+		"accountId":   tenantID,
+		"account": map[string]interface{}{
+			"id": tenantID,
+		},
+		"config": config,
+	}
+}
+
+// cloudConfig returns the configured settings as returned by the API.
+// The API returns every setting of the cloud type, only the ones already
+// in config are kept so unset defaults do not show up as drift. The API
+// masks credentials, so the configured value of those is kept as is.
+func cloudConfig(d *schema.ResourceData, apiConfig map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{})
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		apiValue, ok := apiConfig[k]
+		if !ok || apiValue == nil || isSensitiveCloudSetting(k) {
+			config[k] = v
+			continue
+		}
+		switch apiValue := apiValue.(type) {
+		case string:
+			config[k] = apiValue
+		case bool, float64:
+			config[k] = fmt.Sprintf("%v", apiValue)
+		default:
+			// nested settings are not supported by a string map
+			config[k] = v
+		}
+	}
+	return config
+}
+
+// cloudImportConfig returns the settings of an imported cloud to keep in
+// config, every setting with a value except the masked credentials.
+func cloudImportConfig(apiConfig map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{})
+	for k, v := range apiConfig {
+		if isSensitiveCloudSetting(k) {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			if v != "" {
+				config[k] = v
+			}
+		case bool, float64:
+			config[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	return config
+}
+
+// isSensitiveCloudSetting reports whether the API masks the value of the
+// cloud setting with the given key.
+func isSensitiveCloudSetting(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"password", "secret", "privatekey", "token", "apikey"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -105,7 +105,7 @@ func resourceCloudFormationAppBlueprint() *schema.Resource {
 }
 
 func resourceCloudFormationAppBlueprintCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -177,7 +177,7 @@ func resourceCloudFormationAppBlueprintCreate(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudFormationAppBlueprintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -200,7 +200,9 @@ func resourceCloudFormationAppBlueprintRead(ctx context.Context, d *schema.Resou
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -239,7 +241,7 @@ func resourceCloudFormationAppBlueprintRead(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudFormationAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	name := d.Get("name").(string)
@@ -306,7 +308,7 @@ func resourceCloudFormationAppBlueprintUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudFormationAppBlueprintDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -317,7 +319,8 @@ func resourceCloudFormationAppBlueprintDelete(ctx context.Context, d *schema.Res
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
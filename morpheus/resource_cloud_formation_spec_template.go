@@ -81,7 +81,7 @@ func resourceCloudFormationSpecTemplate() *schema.Resource {
 }
 
 func resourceCloudFormationSpecTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -150,7 +150,7 @@ func resourceCloudFormationSpecTemplateCreate(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudFormationSpecTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -173,7 +173,9 @@ func resourceCloudFormationSpecTemplateRead(ctx context.Context, d *schema.Resou
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -224,7 +226,7 @@ func resourceCloudFormationSpecTemplateRead(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudFormationSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -288,7 +290,7 @@ func resourceCloudFormationSpecTemplateUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudFormationSpecTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -299,7 +301,8 @@ func resourceCloudFormationSpecTemplateDelete(ctx context.Context, d *schema.Res
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
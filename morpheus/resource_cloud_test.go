@@ -0,0 +1,195 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceMorpheusCloud_lifecycle(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_cloud", "/api/zones",
+		map[string]interface{}{
+			"name":     "tfnutanix",
+			"type":     "nutanix",
+			"location": "denver",
+			"config": map[string]interface{}{
+				"apiUrl":   "https://prism.example.com:9440",
+				"username": "admin",
+			},
+			"sensitive_config": map[string]interface{}{
+				"password": "Password123",
+			},
+		},
+		map[string]interface{}{
+			"name":     "tfnutanix",
+			"type":     "nutanix",
+			"location": "boulder",
+			"config": map[string]interface{}{
+				"apiUrl":   "https://prism.example.com:9440",
+				"username": "svc-morpheus",
+			},
+			"sensitive_config": map[string]interface{}{
+				"password": "Password123",
+			},
+		},
+	)
+}
+
+func TestResourceMorpheusCloud_config(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+
+	config := map[string]interface{}{
+		"name": "tfopenstack",
+		"type": "openstack",
+		"config": map[string]interface{}{
+			"identityUrl": "https://keystone.example.com:5000/v3",
+			"password":    "Password123",
+		},
+		"sensitive_config": map[string]interface{}{
+			"clientSecret": "Secret123",
+		},
+	}
+	state := testResourceApply(t, meta, "morpheus_cloud", nil, config)
+
+	zone := s.record("/api/zones", toInt64(state.ID))
+	sent := zone["config"].(map[string]interface{})
+	if sent["identityUrl"] != "https://keystone.example.com:5000/v3" || sent["password"] != "Password123" || sent["clientSecret"] != "Secret123" {
+		t.Fatalf("expected config and sensitive_config to be sent, got %v", sent)
+	}
+	if zone["zoneType"].(map[string]interface{})["code"] != "openstack" {
+		t.Fatalf("expected zoneType openstack, got %v", zone["zoneType"])
+	}
+
+	// the API masks credentials and returns settings that were not configured
+	sent["password"] = "************"
+	sent["clientSecret"] = "************"
+	sent["identityUrl"] = "https://keystone.example.com:5000/v3/"
+	sent["domainId"] = "default"
+
+	state = testResourceRefresh(t, meta, "morpheus_cloud", state)
+	if state.Attributes["config.password"] != "Password123" {
+		t.Fatalf("expected masked password to keep the configured value, got %q", state.Attributes["config.password"])
+	}
+	if state.Attributes["config.identityUrl"] != "https://keystone.example.com:5000/v3/" {
+		t.Fatalf("expected identityUrl to be read from the API, got %q", state.Attributes["config.identityUrl"])
+	}
+	if _, ok := state.Attributes["config.domainId"]; ok {
+		t.Fatal("expected settings that were not configured to be ignored")
+	}
+	if state.Attributes["sensitive_config.clientSecret"] != "Secret123" {
+		t.Fatalf("expected sensitive_config to be kept, got %q", state.Attributes["sensitive_config.clientSecret"])
+	}
+}
+
+func TestResourceMorpheusCloud_import(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	id := s.seed("/api/zones", map[string]interface{}{
+		"name":       "tfnutanix",
+		"visibility": "private",
+		"enabled":    true,
+		"zoneType":   map[string]interface{}{"code": "nutanix"},
+		"config": map[string]interface{}{
+			"apiUrl":         "https://prism.example.com:9440",
+			"username":       "admin",
+			"password":       "************",
+			"passwordHash":   "0123456789abcdef",
+			"importExisting": true,
+			"diskMode":       "",
+		},
+	})
+
+	state := testResourceImport(t, meta, "morpheus_cloud", fmt.Sprint(id))
+	if state.Attributes["config.apiUrl"] != "https://prism.example.com:9440" || state.Attributes["config.importExisting"] != "true" {
+		t.Fatalf("expected the config of the cloud to be imported, got %v", state.Attributes)
+	}
+	for _, key := range []string{"config.password", "config.passwordHash", "config.diskMode"} {
+		if _, ok := state.Attributes[key]; ok {
+			t.Fatalf("expected %s not to be imported", key)
+		}
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_cloud", state, map[string]interface{}{
+		"name": "tfnutanix",
+		"type": "nutanix",
+		"config": map[string]interface{}{
+			"apiUrl":         "https://prism.example.com:9440",
+			"username":       "admin",
+			"importExisting": "true",
+		},
+	})
+}
+
+func TestResourceMorpheusCloud_disappears(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_cloud", "/api/zones", map[string]interface{}{
+		"name": "tfcloud",
+		"type": "nutanix",
+	})
+}
+
+// testFastCloudSync stops new clouds from waiting minutes before checking
+// on their initial sync.
+func testFastCloudSync(t *testing.T) {
+	delay, interval := cloudSyncDelay, cloudSyncPollInterval
+	cloudSyncDelay, cloudSyncPollInterval = 0, 10*time.Millisecond
+	t.Cleanup(func() {
+		cloudSyncDelay, cloudSyncPollInterval = delay, interval
+	})
+}
+
+// testCloudSyncFailure checks that a cloud whose initial sync fails is kept
+// in state, so it is replaced on the next apply.
+func testCloudSyncFailure(t *testing.T, name string, config map[string]interface{}) {
+	t.Helper()
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	s.addCreateHook("/api/zones", func(record map[string]interface{}, body map[string]interface{}) {
+		record["status"] = "error"
+	})
+	meta := testProviderMeta(t, s)
+
+	r := testResource(t, name)
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning %s: %s", name, err)
+	}
+	state, diags := r.Apply(ctx, nil, diff, meta)
+	if !diags.HasError() {
+		t.Fatalf("expected creating %s to fail", name)
+	}
+	if state == nil || state.ID == "" {
+		t.Fatalf("expected the failed %s to be kept in state", name)
+	}
+}
+
+// testMockCloudSecrets makes the mock server mask cloud credentials and
+// return their SHA-256 hash the way the API does.
+func testMockCloudSecrets(s *mockServer) {
+	s.addSaveHook("/api/zones", func(record map[string]interface{}) {
+		config, ok := record["config"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, key := range []string{"password", "secretKey", "clientSecret", "privateKey"} {
+			value, ok := config[key].(string)
+			if !ok || value == "" || value == "************" {
+				continue
+			}
+			hash := sha256.Sum256([]byte(value))
+			config[key+"Hash"] = hex.EncodeToString(hash[:])
+			config[key] = "************"
+		}
+	})
+}
@@ -0,0 +1,597 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCluster() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus cluster resource.",
+		CreateContext: resourceClusterCreate,
+		ReadContext:   resourceClusterRead,
+		UpdateContext: resourceClusterUpdate,
+		DeleteContext: resourceClusterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the cluster",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the cluster",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the cluster",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"cluster_type": {
+				Description: "The code of the cluster type (i.e. - kubernetes-cluster, docker-cluster)",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"layout_id": {
+				Description: "The ID of the cluster layout",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cloud_id": {
+				Description: "The ID of the cloud to provision the cluster in",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the group the cluster belongs to",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"plan_id": {
+				Description: "The ID of the service plan of the master nodes",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"worker_plan_id": {
+				Description: "The ID of the service plan of the worker nodes, defaults to plan_id",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"worker_count": {
+				Description:  "The number of worker nodes, workers are added and removed in place",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"resource_pool_id": {
+				Description: "The ID of the resource pool to provision the nodes in",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"network_id": {
+				Description: "The ID of the network to attach the nodes to",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"status": {
+				Description: "The status of the cluster",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"api_url": {
+				Description: "The URL of the cluster API",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubeconfig": {
+				Description: "The kubeconfig of a Kubernetes cluster",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// clusterPendingStates are the statuses of a cluster that is still being
+// provisioned or scaled. Any status other than these and ok, such as failed,
+// warning or deprovisioning, is one the cluster does not recover from.
+var clusterPendingStates = []string{"pending", "provisioning", "syncing", "scaling"}
+
+// configuredWorkerCount returns the worker_count set in the configuration.
+// GetOk can not tell a worker_count of 0 apart from an unset one.
+func configuredWorkerCount(d *schema.ResourceData) (int, bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		v, ok := d.GetOk("worker_count")
+		return v.(int), ok
+	}
+	if v := config.GetAttr("worker_count"); v.IsNull() || !v.IsKnown() {
+		return 0, false
+	}
+	return d.Get("worker_count").(int), true
+}
+
+func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	server := clusterServerPayload(d)
+	server["plan"] = map[string]interface{}{
+		"id": d.Get("plan_id").(int),
+	}
+	if workerPlanID := d.Get("worker_plan_id").(int); workerPlanID != 0 {
+		server["workerPlan"] = map[string]interface{}{
+			"id": workerPlanID,
+		}
+	}
+	if workerCount, ok := configuredWorkerCount(d); ok {
+		server["nodeCount"] = workerCount
+	}
+
+	cluster := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"type":        d.Get("cluster_type").(string),
+		"layout": map[string]interface{}{
+			"id": d.Get("layout_id").(int),
+		},
+		"cloud": map[string]interface{}{
+			"id": d.Get("cloud_id").(int),
+		},
+		"group": map[string]interface{}{
+			"id": d.Get("group_id").(int),
+		},
+		"server": server,
+	}
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"cluster": cluster,
+		},
+	}
+	resp, err := client.CreateCluster(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateClusterResult)
+	if !result.Success || result.Cluster == nil {
+		return diag.Errorf("error creating cluster: %s", result.Message)
+	}
+
+	// Set the id before waiting so that a failed cluster is tainted
+	d.SetId(int64ToString(result.Cluster.ID))
+	workerCount, ok := configuredWorkerCount(d)
+	if !ok {
+		workerCount = -1
+	}
+	if err := waitForCluster(ctx, client, result.Cluster.ID, workerCount, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error creating cluster: %s", err)
+	}
+
+	// Successfully created resource
+	resourceClusterRead(ctx, d, meta)
+	return diags
+}
+
+func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.GetCluster(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*morpheus.GetClusterResult)
+	cluster := result.Cluster
+	if cluster == nil {
+		return diag.Errorf("Cluster not found in response data.") // should not happen
+	}
+
+	d.SetId(int64ToString(cluster.ID))
+	d.Set("name", cluster.Name)
+	d.Set("description", cluster.Description)
+	d.Set("cloud_id", cluster.Cloud["id"])
+	d.Set("group_id", cluster.Group["id"])
+	if layout, ok := cluster.Layout.(map[string]interface{}); ok {
+		d.Set("layout_id", layout["id"])
+	}
+	switch clusterType := cluster.Type.(type) {
+	case map[string]interface{}:
+		d.Set("cluster_type", clusterType["code"])
+	case string:
+		d.Set("cluster_type", clusterType)
+	}
+	d.Set("status", cluster.Status)
+
+	workers, err := listClusterWorkers(client, cluster.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("worker_count", len(workers))
+	if len(workers) > 0 && workers[0].Plan.ID != 0 {
+		d.Set("worker_plan_id", workers[0].Plan.ID)
+	}
+
+	// The plan, resource pool and network of the cluster are those of its
+	// master nodes, they are kept as they are when they can not be read
+	if err := setClusterMasterAttributes(client, d, cluster.ID); err != nil {
+		log.Printf("[WARN] Unable to read the master nodes of cluster %d: %s", cluster.ID, err)
+	}
+
+	// The API config is only available once the cluster is up and the
+	// kubeconfig only for Kubernetes clusters, missing values are left empty
+	var apiConfig struct {
+		ServiceURL string `json:"serviceUrl"`
+	}
+	if err := getClusterConfig(client, cluster.ID, "api-config", &apiConfig); err != nil {
+		log.Printf("[WARN] Unable to read the API config of cluster %d: %s", cluster.ID, err)
+	}
+	d.Set("api_url", apiConfig.ServiceURL)
+	var kubeConfig struct {
+		KubeConfig string `json:"kubeConfig"`
+	}
+	if err := getClusterConfig(client, cluster.ID, "kube-config", &kubeConfig); err != nil {
+		log.Printf("[WARN] Unable to read the kubeconfig of cluster %d: %s", cluster.ID, err)
+	}
+	d.Set("kubeconfig", kubeConfig.KubeConfig)
+	return diags
+}
+
+func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := toInt64(d.Id())
+
+	if d.HasChanges("name", "description") {
+		req := &morpheus.Request{
+			Body: map[string]interface{}{
+				"cluster": map[string]interface{}{
+					"name":        d.Get("name").(string),
+					"description": d.Get("description").(string),
+				},
+			},
+		}
+		resp, err := client.UpdateCluster(id, req)
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+	}
+
+	if d.HasChange("worker_count") {
+		if err := scaleClusterWorkers(client, d, id); err != nil {
+			return diag.Errorf("error scaling cluster workers: %s", err)
+		}
+		if err := waitForCluster(ctx, client, id, d.Get("worker_count").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error scaling cluster workers: %s", err)
+		}
+	}
+
+	return resourceClusterRead(ctx, d, meta)
+}
+
+func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := toInt64(d.Id())
+	req := &morpheus.Request{
+		QueryParams: map[string]string{
+			"removeResources": "on",
+		},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.DeleteCluster(id, req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// The nodes of the cluster are removed in the background
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"removing"},
+		Target:  []string{"removed"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetCluster(id, &morpheus.Request{})
+			if err != nil {
+				if resp != nil && resp.StatusCode == 404 {
+					return "", "removed", nil
+				}
+				return nil, "", err
+			}
+			return resp, "removing", nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		MinTimeout:   instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error deleting cluster: %s", err)
+	}
+	d.SetId("")
+	return diags
+}
+
+// clusterServerPayload returns the settings shared by the nodes of a new
+// cluster and the workers added to it.
+func clusterServerPayload(d *schema.ResourceData) map[string]interface{} {
+	config := make(map[string]interface{})
+	if d.Get("resource_pool_id").(int) != 0 {
+		config["resourcePoolId"] = d.Get("resource_pool_id").(int)
+	}
+	server := map[string]interface{}{
+		"name":   d.Get("name").(string),
+		"config": config,
+	}
+	if d.Get("network_id").(int) != 0 {
+		server["networkInterfaces"] = []map[string]interface{}{
+			{
+				"network": map[string]interface{}{
+					"id": fmt.Sprintf("network-%d", d.Get("network_id").(int)),
+				},
+			},
+		}
+	}
+	return server
+}
+
+// clusterWorker is a node as returned by the cluster workers and masters
+// endpoints.
+type clusterWorker struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Plan   struct {
+		ID int64 `json:"id"`
+	} `json:"plan"`
+}
+
+// listClusterWorkers returns the worker nodes of a cluster.
+func listClusterWorkers(client *morpheus.Client, id int64) ([]clusterWorker, error) {
+	return listClusterNodes(client, id, "workers")
+}
+
+// listClusterNodes returns the workers or masters of a cluster.
+func listClusterNodes(client *morpheus.Client, id int64, kind string) ([]clusterWorker, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%d/%s", morpheus.ClustersPath, id, kind),
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	var nodes []clusterWorker
+	if raw, ok := result[kind]; ok {
+		if err := json.Unmarshal(raw, &nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// setClusterMasterAttributes reads the plan, resource pool and network of
+// the cluster from its first master node.
+func setClusterMasterAttributes(client *morpheus.Client, d *schema.ResourceData, id int64) error {
+	masters, err := listClusterNodes(client, id, "masters")
+	if err != nil {
+		return err
+	}
+	if len(masters) == 0 {
+		return nil
+	}
+	if masters[0].Plan.ID != 0 {
+		d.Set("plan_id", masters[0].Plan.ID)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%d", serversPath, masters[0].ID),
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	var result struct {
+		Server struct {
+			Config     map[string]interface{}   `json:"config"`
+			Interfaces []map[string]interface{} `json:"interfaces"`
+		} `json:"server"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return err
+	}
+	if pool := instanceResourceID(result.Server.Config["resourcePoolId"]); pool != 0 {
+		d.Set("resource_pool_id", pool)
+	}
+	if len(result.Server.Interfaces) > 0 {
+		if network := instanceResourceID(result.Server.Interfaces[0]["network"]); network != 0 {
+			d.Set("network_id", network)
+		}
+	}
+	return nil
+}
+
+// scaleClusterWorkers adds or removes workers to reach the worker count,
+// the most recently added workers are removed first.
+func scaleClusterWorkers(client *morpheus.Client, d *schema.ResourceData, id int64) error {
+	workers, err := listClusterWorkers(client, id)
+	if err != nil {
+		return err
+	}
+	count := d.Get("worker_count").(int)
+
+	if count > len(workers) {
+		server := clusterServerPayload(d)
+		server["nodeCount"] = count - len(workers)
+		planID := d.Get("worker_plan_id").(int)
+		if planID == 0 {
+			planID = d.Get("plan_id").(int)
+		}
+		server["plan"] = map[string]interface{}{
+			"id": planID,
+		}
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "POST",
+			Path:   fmt.Sprintf("%s/%d/servers", morpheus.ClustersPath, id),
+			Body: map[string]interface{}{
+				"server": server,
+			},
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return err
+		}
+		log.Printf("API RESPONSE: %s", resp)
+		return nil
+	}
+
+	sort.Slice(workers, func(i, j int) bool { return workers[i].ID > workers[j].ID })
+	for _, worker := range workers[:len(workers)-count] {
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "DELETE",
+			Path:   fmt.Sprintf("%s/%d/workers/%d", morpheus.ClustersPath, id, worker.ID),
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return err
+		}
+		log.Printf("API RESPONSE: %s", resp)
+	}
+	return nil
+}
+
+// waitForCluster waits for the cluster to be ok with the given number of
+// workers, a negative count skips checking the workers.
+func waitForCluster(ctx context.Context, client *morpheus.Client, id int64, workerCount int, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"provisioning", "syncing", "scaling"},
+		Target:  []string{"ok"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetCluster(id, &morpheus.Request{})
+			if err != nil {
+				return nil, "", err
+			}
+			cluster := resp.Result.(*morpheus.GetClusterResult).Cluster
+			if cluster.Status != "ok" {
+				for _, status := range clusterPendingStates {
+					if cluster.Status == status {
+						return cluster, "provisioning", nil
+					}
+				}
+				// the SDK does not decode the reason of the status
+				var result struct {
+					Cluster struct {
+						StatusMessage string `json:"statusMessage"`
+					} `json:"cluster"`
+				}
+				json.Unmarshal(resp.Body, &result)
+				if result.Cluster.StatusMessage != "" {
+					return cluster, cluster.Status, fmt.Errorf("cluster %s is %s: %s", cluster.Name, cluster.Status, result.Cluster.StatusMessage)
+				}
+				return cluster, cluster.Status, fmt.Errorf("cluster %s is %s", cluster.Name, cluster.Status)
+			}
+			if workerCount >= 0 {
+				workers, err := listClusterWorkers(client, id)
+				if err != nil {
+					return nil, "", err
+				}
+				if len(workers) != workerCount {
+					return cluster, "scaling", nil
+				}
+			}
+			return cluster, "ok", nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionDelay,
+		PollInterval: instanceProvisionPollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// getClusterConfig reads one of the connection settings endpoints of a
+// cluster, such as its kube-config, into result.
+func getClusterConfig(client *morpheus.Client, id int64, name string, result interface{}) error {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%d/%s", morpheus.ClustersPath, id, name),
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	return json.Unmarshal(resp.Body, result)
+}
@@ -204,7 +204,7 @@ func resourceClusterLayout() *schema.Resource {
 }
 
 func resourceClusterLayoutCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -299,7 +299,7 @@ func resourceClusterLayoutCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceClusterLayoutRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -322,7 +322,9 @@ func resourceClusterLayoutRead(ctx context.Context, d *schema.ResourceData, meta
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -397,7 +399,7 @@ func resourceClusterLayoutRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceClusterLayoutUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	clusterLayout := make(map[string]interface{})
@@ -485,7 +487,7 @@ func resourceClusterLayoutUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceClusterLayoutDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -496,7 +498,8 @@ func resourceClusterLayoutDelete(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -113,7 +113,7 @@ func resourceClusterResourceNamePolicy() *schema.Resource {
 }
 
 func resourceClusterResourceNamePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -191,7 +191,7 @@ func resourceClusterResourceNamePolicyCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceClusterResourceNamePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -212,7 +212,9 @@ func resourceClusterResourceNamePolicyRead(ctx context.Context, d *schema.Resour
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -262,7 +264,7 @@ func resourceClusterResourceNamePolicyRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceClusterResourceNamePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -337,7 +339,7 @@ func resourceClusterResourceNamePolicyUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceClusterResourceNamePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -348,7 +350,8 @@ func resourceClusterResourceNamePolicyDelete(ctx context.Context, d *schema.Reso
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -0,0 +1,239 @@
+package morpheus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testMockClusters makes the mock server provision clusters the way the
+// API does, with their workers served from a nested collection.
+func testMockClusters(s *mockServer) {
+	addWorkers := func(record map[string]interface{}, server map[string]interface{}, plan interface{}) {
+		path := fmt.Sprintf("/api/clusters/%d/workers", record["id"])
+		var workers *mockCollection
+		for _, c := range s.collections {
+			if c.path == path {
+				workers = c
+			}
+		}
+		if workers == nil {
+			workers = s.newCollection(path, "worker", "workers", map[string]interface{}{"status": "ok"})
+		}
+		count, ok := server["nodeCount"].(float64)
+		if !ok {
+			count = 2
+		}
+		for i := 0; i < int(count); i++ {
+			s.insert(workers, map[string]interface{}{"name": fmt.Sprintf("%s-worker", record["name"]), "plan": plan})
+		}
+	}
+	s.addCreateHook("/api/clusters", func(record map[string]interface{}, body map[string]interface{}) {
+		server := record["server"].(map[string]interface{})
+		workerPlan, ok := server["workerPlan"]
+		if !ok {
+			workerPlan = server["plan"]
+		}
+		addWorkers(record, server, workerPlan)
+
+		// the master node is a server carrying the plan, resource pool and
+		// network of the cluster
+		var interfaces []interface{}
+		if networks, ok := server["networkInterfaces"].([]interface{}); ok {
+			interfaces = networks
+		}
+		var id int64
+		for _, c := range s.collections {
+			if c.path == "/api/servers" {
+				id = s.insert(c, map[string]interface{}{"name": fmt.Sprintf("%s-master", record["name"]), "plan": server["plan"], "config": server["config"], "interfaces": interfaces})
+			}
+		}
+		masters := s.newCollection(fmt.Sprintf("/api/clusters/%d/masters", record["id"]), "master", "masters", map[string]interface{}{"status": "ok"})
+		masters.records[id] = map[string]interface{}{"id": id, "name": fmt.Sprintf("%s-master", record["name"]), "status": "ok", "plan": server["plan"]}
+	})
+	s.addAction("/api/clusters", "servers", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		server := body["server"].(map[string]interface{})
+		addWorkers(record, server, server["plan"])
+		return 200, map[string]interface{}{"success": true}
+	})
+	s.addAction("/api/clusters", "api-config", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		return 200, map[string]interface{}{"success": true, "serviceUrl": "https://10.0.0.5:6443"}
+	})
+	s.addAction("/api/clusters", "kube-config", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		return 200, map[string]interface{}{"success": true, "kubeConfig": "apiVersion: v1\nkind: Config\n"}
+	})
+}
+
+func testClusterConfig(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":             name,
+		"description":      "created by terraform",
+		"cluster_type":     "kubernetes-cluster",
+		"layout_id":        3,
+		"cloud_id":         1,
+		"group_id":         1,
+		"plan_id":          10,
+		"worker_plan_id":   11,
+		"worker_count":     2,
+		"resource_pool_id": 5,
+		"network_id":       12,
+	}
+}
+
+func TestResourceMorpheusCluster_lifecycle(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	update := testClusterConfig("tfcluster")
+	update["description"] = "updated by terraform"
+	update["worker_count"] = 3
+	testResourceLifecycle(t, s, "morpheus_cluster", "/api/clusters", testClusterConfig("tfcluster"), update)
+}
+
+func TestResourceMorpheusCluster_payload(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_cluster", nil, testClusterConfig("tfcluster"))
+	cluster := s.requestsFor("POST", "/api/clusters")[0].Body["cluster"].(map[string]interface{})
+	if cluster["type"] != "kubernetes-cluster" || cluster["layout"].(map[string]interface{})["id"] != float64(3) {
+		t.Fatalf("unexpected cluster payload: %v", cluster)
+	}
+	server := cluster["server"].(map[string]interface{})
+	if server["nodeCount"] != float64(2) || server["plan"].(map[string]interface{})["id"] != float64(10) || server["workerPlan"].(map[string]interface{})["id"] != float64(11) {
+		t.Fatalf("unexpected server payload: %v", server)
+	}
+	if server["config"].(map[string]interface{})["resourcePoolId"] != float64(5) {
+		t.Fatalf("expected the resource pool to be sent, got %v", server["config"])
+	}
+
+	if state.Attributes["api_url"] != "https://10.0.0.5:6443" || !strings.HasPrefix(state.Attributes["kubeconfig"], "apiVersion: v1") {
+		t.Fatalf("expected the connection settings to be read, got %v", state.Attributes)
+	}
+	for _, key := range []string{"api_url", "kubeconfig"} {
+		if !testResource(t, "morpheus_cluster").Schema[key].Sensitive {
+			t.Fatalf("expected %s to be sensitive", key)
+		}
+	}
+}
+
+func TestResourceMorpheusCluster_noWorkers(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	meta := testProviderMeta(t, s)
+	config := testClusterConfig("tfcluster")
+	config["worker_count"] = 0
+
+	state := testResourceApply(t, meta, "morpheus_cluster", nil, config)
+	server := s.requestsFor("POST", "/api/clusters")[0].Body["cluster"].(map[string]interface{})["server"].(map[string]interface{})
+	if server["nodeCount"] != float64(0) {
+		t.Fatalf("expected a node count of 0 to be sent, got %v", server)
+	}
+	if state.Attributes["worker_count"] != "0" {
+		t.Fatalf("expected no workers, got %s", state.Attributes["worker_count"])
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_cluster", state, config)
+}
+
+func TestResourceMorpheusCluster_scaleWorkers(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	meta := testProviderMeta(t, s)
+	config := testClusterConfig("tfcluster")
+	config["worker_count"] = 3
+
+	state := testResourceApply(t, meta, "morpheus_cluster", nil, config)
+	workersPath := "/api/clusters/" + state.ID + "/workers"
+	workers := s.collection(workersPath)
+	var ids []int64
+	for id := range workers.records {
+		ids = append(ids, id)
+	}
+
+	config["worker_count"] = 4
+	state = testResourceApply(t, meta, "morpheus_cluster", state, config)
+	server := s.requestsFor("POST", "/api/clusters/"+state.ID+"/servers")[0].Body["server"].(map[string]interface{})
+	if server["nodeCount"] != float64(1) || server["plan"].(map[string]interface{})["id"] != float64(11) {
+		t.Fatalf("expected one worker to be added with the worker plan, got %v", server)
+	}
+	if state.Attributes["worker_count"] != "4" {
+		t.Fatalf("expected 4 workers, got %s", state.Attributes["worker_count"])
+	}
+
+	config["worker_count"] = 1
+	state = testResourceApply(t, meta, "morpheus_cluster", state, config)
+	var removed int
+	for _, r := range s.requests {
+		if r.Method == "DELETE" && strings.HasPrefix(r.Path, workersPath+"/") {
+			removed++
+		}
+	}
+	if removed != 3 || len(workers.records) != 1 {
+		t.Fatalf("expected 3 workers to be removed, got %d", removed)
+	}
+	for id := range workers.records {
+		if id != ids[0] && id != ids[1] && id != ids[2] {
+			t.Fatalf("expected the newest workers to be removed first, %d is left", id)
+		}
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_cluster", state, config)
+}
+
+func TestResourceMorpheusCluster_import(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	meta := testProviderMeta(t, s)
+	config := testClusterConfig("tfcluster")
+
+	state := testResourceApply(t, meta, "morpheus_cluster", nil, config)
+	imported := testResourceImport(t, meta, "morpheus_cluster", state.ID)
+	for key, want := range map[string]string{"plan_id": "10", "worker_plan_id": "11", "resource_pool_id": "5", "network_id": "12"} {
+		if imported.Attributes[key] != want {
+			t.Fatalf("expected %s to be read as %s, got %q", key, want, imported.Attributes[key])
+		}
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_cluster", imported, config)
+
+	delete(config, "worker_plan_id")
+	delete(config, "resource_pool_id")
+	delete(config, "network_id")
+	testResourcePlanEmpty(t, meta, "morpheus_cluster", imported, config)
+}
+
+func TestResourceMorpheusCluster_failed(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	s.addCreateHook("/api/clusters", func(record map[string]interface{}, body map[string]interface{}) {
+		record["status"] = "failed"
+	})
+	meta := testProviderMeta(t, s)
+
+	if err := testResourceApplyError(t, meta, "morpheus_cluster", nil, testClusterConfig("tfcluster")); !strings.Contains(err, "cluster tfcluster is failed") {
+		t.Fatalf("expected the cluster to fail, got %q", err)
+	}
+	// statuses other than the provisioning ones fail with their reason
+	// rather than waiting for the timeout
+	for _, status := range []string{"warning", "deprovisioning"} {
+		s.addCreateHook("/api/clusters", func(record map[string]interface{}, body map[string]interface{}) {
+			record["status"] = status
+			record["statusMessage"] = "worker join timed out"
+		})
+		expected := fmt.Sprintf("cluster tfcluster is %s: worker join timed out", status)
+		if err := testResourceApplyError(t, meta, "morpheus_cluster", nil, testClusterConfig("tfcluster")); !strings.Contains(err, expected) {
+			t.Fatalf("expected %q, got %q", expected, err)
+		}
+	}
+}
+
+func TestResourceMorpheusCluster_disappears(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockClusters(s)
+	testResourceDisappears(t, s, "morpheus_cluster", "/api/clusters", testClusterConfig("tfcluster"))
+}
@@ -0,0 +1,17 @@
+package morpheus
+
+import "testing"
+
+func TestResourceContact_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_contact", "/api/monitoring/contacts",
+		map[string]interface{}{
+			"name":          "tf_example_contact",
+			"email_address": "tf@example.com",
+		},
+		map[string]interface{}{
+			"name":          "tf_example_contact",
+			"email_address": "tf-updated@example.com",
+		},
+	)
+}
@@ -103,7 +103,7 @@ func resourceDelayedDeletePolicy() *schema.Resource {
 }
 
 func resourceDelayedDeletePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -173,7 +173,7 @@ func resourceDelayedDeletePolicyCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceDelayedDeletePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -194,7 +194,9 @@ func resourceDelayedDeletePolicyRead(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -243,7 +245,7 @@ func resourceDelayedDeletePolicyRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceDelayedDeletePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -310,7 +312,7 @@ func resourceDelayedDeletePolicyUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceDelayedDeletePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -321,7 +323,8 @@ func resourceDelayedDeletePolicyDelete(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
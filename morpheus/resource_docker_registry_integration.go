@@ -71,7 +71,7 @@ func resourceDockerRegistryIntegration() *schema.Resource {
 }
 
 func resourceDockerRegistryIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -108,7 +108,7 @@ func resourceDockerRegistryIntegrationCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceDockerRegistryIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -129,7 +129,9 @@ func resourceDockerRegistryIntegrationRead(ctx context.Context, d *schema.Resour
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -151,7 +153,7 @@ func resourceDockerRegistryIntegrationRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceDockerRegistryIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -185,7 +187,7 @@ func resourceDockerRegistryIntegrationUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceDockerRegistryIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -196,7 +198,8 @@ func resourceDockerRegistryIntegrationDelete(ctx context.Context, d *schema.Reso
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
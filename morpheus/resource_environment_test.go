@@ -0,0 +1,19 @@
+package morpheus
+
+import "testing"
+
+func TestResourceEnvironment_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_environment", "/api/environments",
+		map[string]interface{}{
+			"name":        "tf_example_environment",
+			"description": "Terraform example environment",
+			"code":        "tfexample",
+		},
+		map[string]interface{}{
+			"name":        "tf_example_environment",
+			"description": "Updated terraform example environment",
+			"code":        "tfexample",
+		},
+	)
+}
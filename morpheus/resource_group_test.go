@@ -0,0 +1,69 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceMorpheusGroup_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_group", "/api/groups",
+		map[string]interface{}{
+			"name":     "tfgroup",
+			"code":     "tfgroup",
+			"location": "denver",
+		},
+		map[string]interface{}{
+			"name":     "tfgroup-updated",
+			"code":     "tfgroup",
+			"location": "boulder",
+		},
+	)
+}
+
+func TestAccMorpheusGroup_basic(t *testing.T) {
+	s := newMockServer(t)
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMockDestroyed(s, "morpheus_group", "/api/groups"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMorpheusGroupConfig(s, "tfgroup", "denver"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("morpheus_group.test", "name", "tfgroup"),
+					resource.TestCheckResourceAttr("morpheus_group.test", "location", "denver"),
+				),
+			},
+			{
+				Config: testAccMorpheusGroupConfig(s, "tfgroup", "boulder"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("morpheus_group.test", "location", "boulder"),
+				),
+			},
+			{
+				ResourceName:      "morpheus_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusGroupConfig(s *mockServer, name string, location string) string {
+	return testAccMockProviderConfig(s) + fmt.Sprintf(`
+resource "morpheus_group" "test" {
+  name     = %q
+  code     = %q
+  location = %q
+}
+`, name, name, location)
+}
+
+func TestResourceMorpheusGroup_disappears(t *testing.T) {
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_group", "/api/groups", map[string]interface{}{
+		"name": "tfgroup",
+	})
+}
@@ -0,0 +1,692 @@
+package morpheus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInstance() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus instance resource for any cloud type.",
+		CreateContext: resourceInstanceCreate,
+		ReadContext:   resourceInstanceRead,
+		UpdateContext: resourceInstanceUpdate,
+		DeleteContext: resourceInstanceDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+		CustomizeDiff: customizeInstanceResizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the instance",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The user friendly description of the instance",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"cloud_id": {
+				Description: "The ID of the cloud associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"group_id": {
+				Description: "The ID of the group associated with the instance",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"instance_type_id": {
+				Description: "The type of instance to provision",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"instance_layout_id": {
+				Description: "The layout to provision the instance from",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"plan_id": {
+				Description: "The service plan associated with the instance, changing the plan resizes the instance in place",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"cores": {
+				Description: "The number of processor cores of the instance, only supported when the service plan allows custom cores",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"memory": {
+				Description: "The amount of memory of the instance in bytes, only supported when the service plan allows custom memory",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"power_state": {
+				Description:  "The power state of the instance (running, stopped, suspended)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "stopped", "suspended"}, false),
+			},
+			"allow_reboot_on_resize": {
+				Description: "Whether the instance may be rebooted to apply a change of the plan, cores, memory, volumes or network interfaces, planning these changes fails otherwise",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"delete_on_failure": {
+				Description: "Whether to delete the instance when provisioning fails, the failed instance is otherwise kept and replaced on the next apply",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"config": {
+				Description: "Cloud specific provisioning settings passed to the API as is (i.e. - resourcePoolId, securityGroups, availabilityZone)",
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"environment": {
+				Description: "The environment to assign the instance to",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"labels": {
+				Type:        schema.TypeList,
+				Description: "The list of labels to add to the instance",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+			"tags": {
+				Description: "Tags to assign to the instance",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"custom_options": {
+				Description: "Custom options to pass to the instance",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"workflow_id": {
+				Description:   "The ID of the provisioning workflow to execute (`workflow_name` can be used alternatively, only one is needed)",
+				Type:          schema.TypeInt,
+				ForceNew:      true,
+				Optional:      true,
+				ConflictsWith: []string{"workflow_name"},
+			},
+			"workflow_name": {
+				Description:   "The name of the provisioning workflow to execute (`workflow_id` can be used alternatively, only one is needed)",
+				Type:          schema.TypeString,
+				ForceNew:      true,
+				Optional:      true,
+				ConflictsWith: []string{"workflow_id"},
+			},
+			"create_user": {
+				Description: "Whether to create a user account on the instance that is associated with the provisioning user account",
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"user_group_id": {
+				Description: "The id of the user group associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"skip_agent_install": {
+				Description: "Whether to skip installation of the Morpheus agent",
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"evar": {
+				Type:        schema.TypeList,
+				Description: "The environment variables to create, changing them replaces the instance",
+				ForceNew:    true,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the environment variable",
+							ForceNew:    true,
+							Optional:    true,
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Description:      "The value of the environment variable",
+							ForceNew:         true,
+							Optional:         true,
+							DiffSuppressFunc: suppressMaskedEnvironmentVariableDiff,
+						},
+						"export": {
+							Type:        schema.TypeBool,
+							Description: "Whether the environment variable is exported as an instance tag",
+							ForceNew:    true,
+							Optional:    true,
+						},
+						"masked": {
+							Type:        schema.TypeBool,
+							Description: "Whether the environment variable is masked for security purposes",
+							ForceNew:    true,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"volumes": {
+				Description: "The instance volumes, data volumes can be added, resized and removed in place",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"root": {
+							Description: "Whether the volume is the root volume of the instance",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"name": {
+							Description: "The name/type of the LV being created",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"size": {
+							Description: "The size of the LV being created",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+						"size_id": {
+							Description: "The ID of an existing LV to assign to the instance",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+						"storage_type": {
+							Description: "The ID of the LV type",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+						"datastore_id": {
+							Description: "The ID of the datastore",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"interfaces": {
+				Description: "The instance network interfaces, interfaces can be added and removed in place",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Description: "The network to assign the network interface to",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+						"ip_address": {
+							Description: "The static IP address to assign to the network interface",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"ip_mode": {
+							Description: "The IP address assignment mode of the network interface (i.e. - dhcp, static)",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"network_interface_type_id": {
+							Description: "The network interface type",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+						"assigned_ip": {
+							Description: "The IP address assigned to the network interface",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"status": {
+				Description: "The status of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"hostname": {
+				Description: "The hostname of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"primary_ip_address": {
+				Description: "The IP address used to connect to the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ip_addresses": {
+				Description: "The IP addresses assigned to the instance",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"server_ids": {
+				Description: "The IDs of the servers (virtual machines) of the instance",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"external_id": {
+				Description: "The ID of the virtual machine in the cloud",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"date_created": {
+				Description: "The date the instance was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	group := d.Get("group_id").(int)
+	cloud := d.Get("cloud_id").(int)
+	name := d.Get("name").(string)
+
+	// Service Plan
+	planResp, err := client.GetPlan(int64(d.Get("plan_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", planResp, err)
+		return diag.FromErr(err)
+	}
+	planResult := planResp.Result.(*morpheus.GetPlanResult)
+	plan := planResult.Plan
+
+	// Instance Type
+	instanceTypeResp, err := client.GetInstanceType(int64(d.Get("instance_type_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", instanceTypeResp, err)
+		return diag.FromErr(err)
+	}
+	instanceTypeResult := instanceTypeResp.Result.(*morpheus.GetInstanceTypeResult)
+	instanceTypeCode := instanceTypeResult.InstanceType.Code
+
+	// Instance Layout
+	instanceLayoutResp, err := client.GetInstanceLayout(int64(d.Get("instance_layout_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", instanceLayoutResp, err)
+		return diag.FromErr(err)
+	}
+	instanceLayoutResult := instanceLayoutResp.Result.(*morpheus.GetInstanceLayoutResult)
+	instanceLayout := instanceLayoutResult.InstanceLayout
+
+	// Config, the cloud specific settings are passed through as is
+	config := make(map[string]interface{})
+	for key, value := range d.Get("config").(map[string]interface{}) {
+		config[key] = value.(string)
+	}
+
+	// Custom Options
+	if d.Get("custom_options") != nil {
+		customOptionsInput := d.Get("custom_options").(map[string]interface{})
+		customOptions := make(map[string]interface{})
+		for key, value := range customOptionsInput {
+			customOptions[key] = value.(string)
+		}
+		config["customOptions"] = customOptions
+	}
+
+	// Create User
+	config["createUser"] = d.Get("create_user").(bool)
+
+	// Skip Agent Install
+	config["noAgent"] = d.Get("skip_agent_install").(bool)
+
+	instancePayload := map[string]interface{}{
+		"name": name,
+		"type": instanceTypeCode,
+		"site": map[string]interface{}{
+			"id": group,
+		},
+		"plan": map[string]interface{}{
+			"id":   plan.ID,
+			"code": plan.Code,
+			"name": plan.Name,
+		},
+		"layout": map[string]interface{}{
+			"id":   instanceLayout.ID,
+			"code": instanceLayout.Code,
+			"name": instanceLayout.Name,
+		},
+	}
+
+	// Description
+	if d.Get("description") != nil {
+		instancePayload["description"] = d.Get("description").(string)
+	}
+
+	// Environment
+	if d.Get("environment") != nil {
+		instancePayload["instanceContext"] = d.Get("environment").(string)
+	}
+
+	// User Group ID
+	if d.Get("user_group_id").(int) != 0 {
+		userGroupPayload := map[string]interface{}{
+			"id": d.Get("user_group_id").(int),
+		}
+		instancePayload["userGroup"] = userGroupPayload
+	}
+
+	payload := map[string]interface{}{
+		"zoneId":   cloud,
+		"instance": instancePayload,
+		"config":   config,
+	}
+
+	// Custom cores and memory
+	servicePlanOptions, err := instanceServicePlanOptions(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(servicePlanOptions) > 0 {
+		payload["servicePlanOptions"] = servicePlanOptions
+	}
+
+	// tags
+	if d.Get("tags") != nil {
+		payload["tags"] = instanceTags(d.Get("tags").(map[string]interface{}))
+	}
+
+	// Labels
+	if d.Get("labels") != nil {
+		payload["labels"] = d.Get("labels")
+	}
+
+	// Provisioning Workflow ID
+	if d.Get("workflow_id").(int) != 0 {
+		payload["taskSetId"] = d.Get("workflow_id")
+	}
+
+	// Provisioning Workflow Name
+	if d.Get("workflow_name").(string) != "" {
+		payload["taskSetName"] = d.Get("workflow_name")
+	}
+
+	// Environment Variables
+	if d.Get("evar") != nil {
+		payload["evars"] = parseEnvironmentVariables(d.Get("evar").([]interface{}))
+	}
+
+	// Network Interfaces
+	if d.Get("interfaces") != nil {
+		payload["networkInterfaces"] = parseNetworkInterfaces(d.Get("interfaces").([]interface{}))
+	}
+
+	// Volumes
+	if d.Get("volumes") != nil {
+		payload["volumes"] = parseStorageVolumes(d.Get("volumes").([]interface{}))
+	}
+
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.CreateInstance(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateInstanceResult)
+	instance := result.Instance
+
+	// Set the id before waiting so that a failed instance is tainted
+	d.SetId(int64ToString(instance.ID))
+	if err := waitForInstanceProvision(ctx, client, instance.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		if d.Get("delete_on_failure").(bool) {
+			if cleanupErr := deleteFailedInstance(client, instance.ID); cleanupErr != nil {
+				return diag.Errorf("error creating instance: %s (deleting the failed instance: %s)", err, cleanupErr)
+			}
+			d.SetId("")
+		}
+		return diag.Errorf("error creating instance: %s", err)
+	}
+
+	// Power the instance off when it is not meant to be running
+	diags = append(diags, setNewInstancePowerState(ctx, client, d, instance.ID)...)
+
+	// Successfully created resource
+	resourceInstanceRead(ctx, d, meta)
+	return diags
+}
+
+func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	name := d.Get("name").(string)
+
+	// lookup by name if we do not have an id yet
+	var resp *morpheus.Response
+	var err error
+	if id == "" && name != "" {
+		resp, err = client.FindInstanceByName(name)
+	} else if id != "" {
+		resp, err = client.GetInstance(toInt64(id), &morpheus.Request{})
+	} else {
+		return diag.Errorf("Instance cannot be read without name or id")
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetInstanceResult)
+	instance := result.Instance
+	if instance == nil {
+		return diag.Errorf("Instance not found in response data.") // should not happen
+	}
+
+	d.SetId(int64ToString(instance.ID))
+	d.Set("name", instance.Name)
+	d.Set("description", instance.Description)
+	d.Set("cloud_id", instance.Cloud["id"])
+	d.Set("group_id", instance.Group["id"])
+	d.Set("instance_type_id", instance.InstanceType["id"])
+	d.Set("instance_layout_id", instance.Layout["id"])
+	d.Set("plan_id", instance.Plan.ID)
+	switch instance.Status {
+	case "running", "stopped", "suspended":
+		d.Set("power_state", instance.Status)
+	}
+	if err := setInstanceServicePlanOptions(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+	assignedIPs, runtimeDiags := setInstanceRuntimeAttributes(client, d, resp)
+	diags = append(diags, runtimeDiags...)
+	if runtimeDiags.HasError() {
+		return diags
+	}
+	d.Set("config", cloudConfig(d, instance.Config))
+	d.Set("environment", instance.Environment)
+	d.Set("labels", instance.Labels)
+	d.Set("evar", flattenInstanceEnvironmentVariables(instance.EnvironmentVariables, d.Get("evar").([]interface{})))
+	d.Set("volumes", flattenInstanceVolumes(instance.Volumes))
+	d.Set("interfaces", flattenInstanceInterfaces(instance.Interfaces, assignedIPs))
+	// Tags
+	tags := make(map[string]interface{})
+	if instance.Tags != nil {
+		output := instance.Tags
+		tagList := *output
+		// iterate over the array of tags
+		for i := 0; i < len(tagList); i++ {
+			tag := tagList[i]
+			tagName := tag["name"]
+			tags[tagName.(string)] = tag["value"]
+		}
+	}
+	d.Set("tags", tags)
+	if instance.Config["userGroup"] != nil {
+		userGroup := instance.Config["userGroup"].(map[string]interface{})
+		d.Set("user_group_id", userGroup["id"])
+	}
+	d.Set("create_user", instance.Config["createUser"])
+	d.Set("skip_agent_install", instance.Config["noAgent"])
+	d.Set("custom_options", flattenInstanceCustomOptions(instance.Config["customOptions"]))
+	return diags
+}
+
+func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	config := make(map[string]interface{})
+
+	// Custom Options
+	if d.Get("custom_options") != nil {
+		customOptionsInput := d.Get("custom_options").(map[string]interface{})
+		customOptions := make(map[string]interface{})
+		for key, value := range customOptionsInput {
+			customOptions[key] = value.(string)
+		}
+		config["customOptions"] = customOptions
+	}
+
+	instancePayload := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"labels":          d.Get("labels"),
+		"instanceContext": d.Get("environment"),
+		"config":          config,
+	}
+
+	// Tags are replaced as a whole, only send them when they changed
+	if d.HasChange("tags") {
+		instancePayload["tags"] = instanceTags(d.Get("tags").(map[string]interface{}))
+	}
+
+	// Moving the instance to another group
+	if d.HasChange("group_id") {
+		instancePayload["site"] = map[string]interface{}{
+			"id": d.Get("group_id").(int),
+		}
+	}
+
+	payload := map[string]interface{}{
+		"instance": instancePayload,
+	}
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.UpdateInstance(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateInstanceResult)
+	instance := result.Instance
+
+	// The plan, volumes and network interfaces are changed by resizing the instance
+	if d.HasChanges("plan_id", "cores", "memory", "volumes", "interfaces") {
+		if err := resizeInstance(ctx, client, d, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error reconfiguring instance: %s", err)
+		}
+	}
+
+	if d.HasChange("power_state") {
+		if err := setInstancePowerState(ctx, client, instance.ID, d.Get("power_state").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error changing the power state of instance: %s", err)
+		}
+	}
+
+	// Successfully updated resource, now set id
+	d.SetId(int64ToString(instance.ID))
+	return resourceInstanceRead(ctx, d, meta)
+}
+
+func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{
+		QueryParams: map[string]string{},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.DeleteInstance(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
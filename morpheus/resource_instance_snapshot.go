@@ -0,0 +1,292 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceInstanceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus instance snapshot resource.",
+		CreateContext: resourceInstanceSnapshotCreate,
+		ReadContext:   resourceInstanceSnapshotRead,
+		UpdateContext: resourceInstanceSnapshotUpdate,
+		DeleteContext: resourceInstanceSnapshotDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the snapshot",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"instance_id": {
+				Description: "The ID of the instance to snapshot",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the snapshot",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "The description of the snapshot",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"revert_trigger": {
+				Description: "An arbitrary value that reverts the instance to the snapshot whenever it changes, the instance is not reverted when the snapshot is created",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "The status of the snapshot",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"date_created": {
+				Description: "The date the snapshot was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInstanceSnapshotImport,
+		},
+	}
+}
+
+// instanceSnapshot is a snapshot as returned by the snapshot endpoints.
+type instanceSnapshot struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	DateCreated string `json:"dateCreated"`
+}
+
+func resourceInstanceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	instanceID := int64(d.Get("instance_id").(int))
+	name := d.Get("name").(string)
+
+	// The API does not return the new snapshot, remember the existing ones
+	// to tell it apart from an older snapshot with the same name
+	existing, err := listInstanceSnapshots(client, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous := make(map[int64]bool)
+	for _, snapshot := range existing {
+		previous[snapshot.ID] = true
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%d/snapshot", morpheus.InstancesPath, instanceID),
+		Body: map[string]interface{}{
+			"snapshot": map[string]interface{}{
+				"name":        name,
+				"description": d.Get("description").(string),
+			},
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "creating"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			snapshots, err := listInstanceSnapshots(client, instanceID)
+			if err != nil {
+				return nil, "", err
+			}
+			var snapshot *instanceSnapshot
+			for i := range snapshots {
+				if snapshots[i].Name == name && !previous[snapshots[i].ID] {
+					snapshot = &snapshots[i]
+				}
+			}
+			if snapshot == nil {
+				return instanceSnapshot{}, "pending", nil
+			}
+			if snapshot.Status == "failed" {
+				d.SetId(int64ToString(snapshot.ID))
+				return snapshot, snapshot.Status, fmt.Errorf("snapshot %s of instance %d failed", name, instanceID)
+			}
+			return snapshot, snapshot.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   instanceProvisionPollInterval,
+		Delay:        instanceProvisionPollInterval,
+		PollInterval: instanceProvisionPollInterval,
+	}
+	snapshot, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error creating snapshot: %s", err)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(snapshot.(*instanceSnapshot).ID))
+	resourceInstanceSnapshotRead(ctx, d, meta)
+	return diags
+}
+
+func resourceInstanceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/snapshots/%s", id),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var result struct {
+		Snapshot instanceSnapshot `json:"snapshot"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	snapshot := result.Snapshot
+
+	d.SetId(int64ToString(snapshot.ID))
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("status", snapshot.Status)
+	d.Set("date_created", snapshot.DateCreated)
+	return diags
+}
+
+func resourceInstanceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	instanceID := int64(d.Get("instance_id").(int))
+
+	if d.HasChange("revert_trigger") {
+		// keep the previous revert_trigger in state when the revert fails,
+		// so that the next apply tries again
+		d.Partial(true)
+
+		// the revert runs as a process of the instance, remember the latest
+		// one to tell the revert apart from the processes before it
+		lastProcessID, err := lastInstanceProcessID(client, instanceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("%s/%d/revert-snapshot/%s", morpheus.InstancesPath, instanceID, id),
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+
+		if err := waitForInstanceProcess(ctx, client, instanceID, lastProcessID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error reverting instance %d to snapshot %s: %s", instanceID, id, err)
+		}
+		d.Partial(false)
+	}
+
+	return resourceInstanceSnapshotRead(ctx, d, meta)
+}
+
+func resourceInstanceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/snapshots/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceInstanceSnapshotImport imports a snapshot by the ID of its
+// instance and its own ID, separated by a colon.
+func resourceInstanceSnapshotImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <instance_id>:<snapshot_id>", d.Id())
+	}
+	d.Set("instance_id", int(toInt64(parts[0])))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// listInstanceSnapshots returns the snapshots of an instance.
+func listInstanceSnapshots(client *morpheus.Client, instanceID int64) ([]instanceSnapshot, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%d/snapshots", morpheus.InstancesPath, instanceID),
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	var result struct {
+		Snapshots []instanceSnapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return result.Snapshots, nil
+}
@@ -0,0 +1,227 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockInstanceSnapshots routes the snapshot actions of instances to the
+// snapshots collection. Snapshots are created in the given status.
+func testMockInstanceSnapshots(s *mockServer, status string) {
+	s.addAction("/api/instances", "snapshot", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		snapshot, _ := body["snapshot"].(map[string]interface{})
+		snapshot = copyMockRecord(snapshot)
+		snapshot["instanceId"] = record["id"]
+		snapshot["status"] = status
+		snapshot["dateCreated"] = "2023-03-01T12:00:00Z"
+		for _, c := range s.collections {
+			if c.path == "/api/snapshots" {
+				s.insert(c, snapshot)
+			}
+		}
+		return 200, map[string]interface{}{"success": true}
+	})
+	s.addAction("/api/instances", "snapshots", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		snapshots := []interface{}{}
+		for _, c := range s.collections {
+			if c.path != "/api/snapshots" {
+				continue
+			}
+			for _, snapshot := range c.records {
+				// ids are numbers once stored, compare them as text
+				if fmt.Sprint(snapshot["instanceId"]) == fmt.Sprint(record["id"]) {
+					snapshots = append(snapshots, snapshot)
+				}
+			}
+		}
+		return 200, map[string]interface{}{"snapshots": snapshots}
+	})
+	s.addAction("/api/instances", "revert-snapshot", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		testMockInstanceProcess(s, record, "revert", status)
+		return 200, map[string]interface{}{"success": true}
+	})
+	s.addAction("/api/instances", "history", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		processes, _ := record["history"].([]interface{})
+		return 200, map[string]interface{}{"processes": processes}
+	})
+}
+
+// testMockInstanceProcess adds a process with the given status to the
+// history of an instance record.
+func testMockInstanceProcess(s *mockServer, record map[string]interface{}, name string, status string) {
+	s.nextID++
+	processes, _ := record["history"].([]interface{})
+	record["history"] = append([]interface{}{
+		map[string]interface{}{"id": float64(s.nextID), "displayName": name, "status": status},
+	}, processes...)
+}
+
+func TestResourceMorpheusInstanceSnapshot_lifecycle(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "complete")
+	meta := testProviderMeta(t, s)
+	instance := s.seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+	config := map[string]interface{}{
+		"instance_id": int(instance),
+		"name":        "pre-patching",
+		"description": "taken before patching",
+	}
+
+	state := testResourceApply(t, meta, "morpheus_instance_snapshot", nil, config)
+	if s.record("/api/snapshots", toInt64(state.ID)) == nil {
+		t.Fatalf("snapshot %s was not created on the server", state.ID)
+	}
+	if state.Attributes["status"] != "complete" || state.Attributes["date_created"] == "" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance_snapshot", state, config)
+
+	imported := testResourceImport(t, meta, "morpheus_instance_snapshot", int64ToString(instance)+":"+state.ID)
+	if imported.ID != state.ID || imported.Attributes["instance_id"] != int64ToString(instance) {
+		t.Fatalf("unexpected imported state: %v", imported.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance_snapshot", imported, config)
+
+	testResourceDestroy(t, meta, "morpheus_instance_snapshot", state)
+	if s.record("/api/snapshots", toInt64(state.ID)) != nil {
+		t.Fatalf("snapshot %s still exists on the server", state.ID)
+	}
+}
+
+func TestResourceMorpheusInstanceSnapshot_sameName(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "complete")
+	meta := testProviderMeta(t, s)
+	instance := s.seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+	older := s.seed("/api/snapshots", map[string]interface{}{"name": "pre-patching", "instanceId": instance})
+
+	state := testResourceApply(t, meta, "morpheus_instance_snapshot", nil, map[string]interface{}{
+		"instance_id": int(instance),
+		"name":        "pre-patching",
+	})
+	if state.ID == int64ToString(older) {
+		t.Fatalf("expected a new snapshot, got the existing snapshot %d", older)
+	}
+}
+
+func TestResourceMorpheusInstanceSnapshot_revert(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "complete")
+	meta := testProviderMeta(t, s)
+	instance := s.seed("/api/instances", map[string]interface{}{
+		"name":    "tfinstance",
+		"status":  "running",
+		"history": []interface{}{map[string]interface{}{"id": 1, "displayName": "provision", "status": "complete"}},
+	})
+	config := map[string]interface{}{
+		"instance_id":    int(instance),
+		"name":           "pre-patching",
+		"revert_trigger": "1",
+	}
+
+	state := testResourceApply(t, meta, "morpheus_instance_snapshot", nil, config)
+	revertPath := "/api/instances/" + int64ToString(instance) + "/revert-snapshot/" + state.ID
+	if len(s.requestsFor("PUT", revertPath)) != 0 {
+		t.Fatal("expected a new snapshot not to be reverted")
+	}
+
+	config["revert_trigger"] = "2"
+	newState := testResourceApply(t, meta, "morpheus_instance_snapshot", state, config)
+	if newState.ID != state.ID {
+		t.Fatalf("expected snapshot %s to be kept, got %s", state.ID, newState.ID)
+	}
+	if len(s.requestsFor("PUT", revertPath)) != 1 {
+		t.Fatal("expected the instance to be reverted to the snapshot")
+	}
+}
+
+func TestResourceMorpheusInstanceSnapshot_revertFailed(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "complete")
+	meta := testProviderMeta(t, s)
+	instance := s.seed("/api/instances", map[string]interface{}{
+		"name":    "tfinstance",
+		"status":  "running",
+		"history": []interface{}{map[string]interface{}{"id": 1, "displayName": "provision", "status": "complete"}},
+	})
+	config := map[string]interface{}{
+		"instance_id":    int(instance),
+		"name":           "pre-patching",
+		"revert_trigger": "1",
+	}
+	state := testResourceApply(t, meta, "morpheus_instance_snapshot", nil, config)
+
+	// the revert fails while the instance keeps running
+	s.addAction("/api/instances", "revert-snapshot", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		testMockInstanceProcess(s, record, "revert", "failed")
+		return 200, map[string]interface{}{"success": true}
+	})
+	config["revert_trigger"] = "2"
+	testInstanceSnapshotRevertError(t, meta, state, config, "revert: failed")
+
+	// so does a revert the API refuses
+	s.addAction("/api/instances", "revert-snapshot", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		return 400, map[string]interface{}{"success": false, "msg": "snapshot is not available"}
+	})
+	testInstanceSnapshotRevertError(t, meta, state, config, "snapshot is not available")
+}
+
+// testInstanceSnapshotRevertError applies a changed revert_trigger that is
+// expected to fail and checks the revert is planned again.
+func testInstanceSnapshotRevertError(t *testing.T, meta interface{}, state *terraform.InstanceState, config map[string]interface{}, expected string) {
+	t.Helper()
+	r := testResource(t, "morpheus_instance_snapshot")
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, expected) {
+		t.Fatalf("expected the failed revert to be reported, got %v", diags)
+	}
+	if newState.Attributes["revert_trigger"] != state.Attributes["revert_trigger"] {
+		t.Fatalf("expected revert_trigger %q to be kept, got %q", state.Attributes["revert_trigger"], newState.Attributes["revert_trigger"])
+	}
+	diff, err = r.Diff(context.Background(), newState, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff == nil || diff.Attributes["revert_trigger"] == nil {
+		t.Fatalf("expected the revert to be planned again, got %#v", diff)
+	}
+}
+
+func TestResourceMorpheusInstanceSnapshot_failed(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "failed")
+	meta := testProviderMeta(t, s)
+	instance := s.seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+
+	err := testResourceApplyError(t, meta, "morpheus_instance_snapshot", nil, map[string]interface{}{
+		"instance_id": int(instance),
+		"name":        "pre-patching",
+	})
+	if !strings.Contains(err, "snapshot pre-patching of instance") {
+		t.Fatalf("expected the snapshot to fail, got %q", err)
+	}
+}
+
+func TestResourceMorpheusInstanceSnapshot_disappears(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testMockInstanceSnapshots(s, "complete")
+	instance := s.seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+	testResourceDisappears(t, s, "morpheus_instance_snapshot", "/api/snapshots", map[string]interface{}{
+		"instance_id": int(instance),
+		"name":        "pre-patching",
+	})
+}
@@ -0,0 +1,604 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testInstanceConfig sets the mock server up to provision instances, seeds
+// the cloud, group, plan, type and layout a new instance refers to and
+// returns the configuration of the instance.
+func testInstanceConfig(s *mockServer, description string) map[string]interface{} {
+	testMockInstances(s)
+	cloud := s.seed("/api/zones", map[string]interface{}{"name": "tfaws", "zoneType": map[string]interface{}{"code": "amazon"}})
+	group := s.seed("/api/groups", map[string]interface{}{"name": "tfgroup"})
+	plan := s.seed("/api/service-plans", map[string]interface{}{"name": "t3.small", "code": "amazon-t3.small"})
+	instanceType := s.seed("/api/library/instance-types", map[string]interface{}{"name": "ubuntu", "code": "ubuntu"})
+	layout := s.seed("/api/library/layouts", map[string]interface{}{"name": "Amazon Ubuntu 22.04", "code": "amazon-ubuntu-22.04"})
+	return map[string]interface{}{
+		"name":               "tfinstance",
+		"description":        description,
+		"cloud_id":           int(cloud),
+		"group_id":           int(group),
+		"instance_type_id":   int(instanceType),
+		"instance_layout_id": int(layout),
+		"plan_id":            int(plan),
+		"environment":        "dev",
+		"labels":             []interface{}{"demo", "terraform"},
+		"tags":               map[string]interface{}{"owner": "terraform"},
+		"config": map[string]interface{}{
+			"resourcePoolId":   "pool-vpc-1",
+			"availabilityZone": "us-east-1a",
+		},
+		"interfaces": []interface{}{
+			map[string]interface{}{"network_id": 12},
+		},
+		"volumes": []interface{}{
+			map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 3},
+		},
+		"evar": []interface{}{
+			map[string]interface{}{"name": "application", "value": "demo", "export": true, "masked": false},
+		},
+	}
+}
+
+func TestResourceMorpheusInstance_lifecycle(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	create := testInstanceConfig(s, "created by terraform")
+	update := make(map[string]interface{})
+	for k, v := range create {
+		update[k] = v
+	}
+	update["description"] = "updated by terraform"
+	update["labels"] = []interface{}{"demo"}
+	update["tags"] = map[string]interface{}{"owner": "ops"}
+	testResourceLifecycle(t, s, "morpheus_instance", "/api/instances", create, update)
+}
+
+func TestResourceMorpheusInstance_payload(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+
+	testResourceApply(t, meta, "morpheus_instance", nil, config)
+	body := s.requestsFor("POST", "/api/instances")[0].Body
+	if body["zoneId"] != float64(config["cloud_id"].(int)) {
+		t.Fatalf("expected zoneId %d, got %v", config["cloud_id"], body["zoneId"])
+	}
+	instance := body["instance"].(map[string]interface{})
+	if instance["type"] != "ubuntu" || instance["layout"].(map[string]interface{})["code"] != "amazon-ubuntu-22.04" || instance["plan"].(map[string]interface{})["code"] != "amazon-t3.small" {
+		t.Fatalf("unexpected instance payload: %v", instance)
+	}
+	sent := body["config"].(map[string]interface{})
+	if sent["resourcePoolId"] != "pool-vpc-1" || sent["availabilityZone"] != "us-east-1a" {
+		t.Fatalf("expected config to be passed through, got %v", sent)
+	}
+	for _, key := range []string{"nestedVirtualization", "smbiosAssetTag"} {
+		if _, ok := sent[key]; ok {
+			t.Fatalf("expected no vSphere specific %s setting, got %v", key, sent)
+		}
+	}
+	interfaces := body["networkInterfaces"].([]interface{})
+	if interfaces[0].(map[string]interface{})["network"].(map[string]interface{})["id"] != "network-12" {
+		t.Fatalf("unexpected network interfaces: %v", interfaces)
+	}
+	volumes := body["volumes"].([]interface{})
+	if volumes[0].(map[string]interface{})["rootVolume"] != true || volumes[0].(map[string]interface{})["size"] != float64(20) {
+		t.Fatalf("unexpected volumes: %v", volumes)
+	}
+	evars := body["evars"].([]interface{})
+	if evars[0].(map[string]interface{})["name"] != "application" {
+		t.Fatalf("unexpected evars: %v", evars)
+	}
+}
+
+func TestResourceMorpheusInstance_config(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, testInstanceConfig(s, "created by terraform"))
+
+	// the API returns every provisioning setting of the instance
+	record := s.record("/api/instances", toInt64(state.ID))
+	record["config"].(map[string]interface{})["securityGroups"] = []interface{}{"sg-1"}
+	record["config"].(map[string]interface{})["availabilityZone"] = "us-east-1b"
+
+	state = testResourceRefresh(t, meta, "morpheus_instance", state)
+	if state.Attributes["config.availabilityZone"] != "us-east-1b" {
+		t.Fatalf("expected availabilityZone to be read from the API, got %q", state.Attributes["config.availabilityZone"])
+	}
+	if _, ok := state.Attributes["config.securityGroups"]; ok {
+		t.Fatal("expected settings that were not configured to be ignored")
+	}
+}
+
+func TestResourceMorpheusInstance_resize(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	plan := s.seed("/api/service-plans", map[string]interface{}{"name": "t3.custom", "code": "amazon-t3.custom", "customCores": true, "customMaxMemory": true})
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+
+	// resizing is refused unless a reboot is allowed
+	config["plan_id"] = int(plan)
+	config["cores"] = 4
+	config["memory"] = 8589934592
+	if err := testResourcePlanError(t, meta, "morpheus_instance", state, config); !strings.Contains(err, "allow_reboot_on_resize") {
+		t.Fatalf("expected resize to require allow_reboot_on_resize, got %q", err)
+	}
+	if requests := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize"); len(requests) != 0 {
+		t.Fatalf("expected no resize request, got %d", len(requests))
+	}
+
+	config["allow_reboot_on_resize"] = true
+	newState := testResourceApply(t, meta, "morpheus_instance", state, config)
+	if newState.ID != state.ID || len(s.requestsFor("POST", "/api/instances")) != 1 {
+		t.Fatal("expected the instance to be resized in place")
+	}
+	body := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")[0].Body
+	if body["instance"].(map[string]interface{})["plan"].(map[string]interface{})["id"] != float64(plan) {
+		t.Fatalf("expected plan %d, got %v", plan, body["instance"])
+	}
+	servicePlanOptions := body["servicePlanOptions"].(map[string]interface{})
+	if servicePlanOptions["maxCores"] != float64(4) || servicePlanOptions["maxMemory"] != float64(8589934592) {
+		t.Fatalf("unexpected service plan options: %v", servicePlanOptions)
+	}
+	if _, ok := body["networkInterfaces"]; ok {
+		t.Fatal("expected unchanged network interfaces not to be sent")
+	}
+
+	state = testResourceRefresh(t, meta, "morpheus_instance", newState)
+	if state.Attributes["plan_id"] != fmt.Sprint(plan) || state.Attributes["cores"] != "4" || state.Attributes["memory"] != "8589934592" {
+		t.Fatalf("unexpected state after resize: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+}
+
+func TestResourceMorpheusInstance_devices(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["allow_reboot_on_resize"] = true
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+	record := s.record("/api/instances", toInt64(state.ID))
+	rootID := record["volumes"].([]interface{})[0].(map[string]interface{})["id"]
+	nicID := record["interfaces"].([]interface{})[0].(map[string]interface{})["id"]
+	if state.Attributes["volumes.0.size"] != "20" || state.Attributes["interfaces.0.network_id"] != "12" || state.Attributes["evar.0.name"] != "application" {
+		t.Fatalf("unexpected devices in state: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+
+	// volumes and interfaces are added in place
+	config["volumes"] = []interface{}{
+		map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 3},
+		map[string]interface{}{"name": "data", "size": 50, "storage_type": 3},
+	}
+	config["interfaces"] = []interface{}{
+		map[string]interface{}{"network_id": 12},
+		map[string]interface{}{"network_id": 14},
+	}
+	newState := testResourceApply(t, meta, "morpheus_instance", state, config)
+	if newState.ID != state.ID || len(s.requestsFor("POST", "/api/instances")) != 1 {
+		t.Fatal("expected the instance to be reconfigured in place")
+	}
+	body := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")[0].Body
+	volumes := body["volumes"].([]interface{})
+	if len(volumes) != 2 || volumes[0].(map[string]interface{})["id"] != rootID || volumes[1].(map[string]interface{})["id"] != float64(-1) {
+		t.Fatalf("expected root volume %v to be kept and a data volume added, got %v", rootID, volumes)
+	}
+	interfaces := body["networkInterfaces"].([]interface{})
+	if len(interfaces) != 2 || interfaces[0].(map[string]interface{})["id"] != nicID {
+		t.Fatalf("expected interface %v to be kept and one added, got %v", nicID, interfaces)
+	}
+	state = testResourceRefresh(t, meta, "morpheus_instance", newState)
+	if state.Attributes["volumes.#"] != "2" || state.Attributes["interfaces.#"] != "2" {
+		t.Fatalf("unexpected devices in state: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+
+	// environment variables can not be changed in place
+	config["evar"] = []interface{}{
+		map[string]interface{}{"name": "application", "value": "demo2", "export": true, "masked": false},
+	}
+	diff, err := testResource(t, "morpheus_instance").Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning morpheus_instance: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatal("expected changing evar to replace the instance")
+	}
+}
+
+func TestResourceMorpheusInstance_resizeWarning(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["allow_reboot_on_resize"] = true
+	plan := s.seed("/api/service-plans", map[string]interface{}{"name": "t3.medium", "code": "amazon-t3.medium"})
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+	s.addAction("/api/instances", "resize", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		record["status"] = "warning"
+		return 200, map[string]interface{}{"success": true, "instance": record}
+	})
+	config["plan_id"] = int(plan)
+	if err := testResourceApplyError(t, meta, "morpheus_instance", state, config); !strings.Contains(err, "is warning after the resize") {
+		t.Fatalf("expected the resize to fail, got %q", err)
+	}
+}
+
+func TestResourceMorpheusInstance_customCoresNotAllowed(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["cores"] = 4
+
+	if err := testResourceApplyError(t, meta, "morpheus_instance", nil, config); !strings.Contains(err, "does not allow custom cores") {
+		t.Fatalf("expected custom cores to be refused, got %q", err)
+	}
+	if requests := s.requestsFor("POST", "/api/instances"); len(requests) != 0 {
+		t.Fatalf("expected no instance to be created, got %d", len(requests))
+	}
+}
+
+// testMockFailedProvision makes new instances fail to provision and
+// returns the id of the last instance created.
+func testMockFailedProvision(s *mockServer) *int64 {
+	var id int64
+	s.addCreateHook("/api/instances", func(record map[string]interface{}, body map[string]interface{}) {
+		id = record["id"].(int64)
+		record["status"] = "failed"
+		record["statusMessage"] = "Failed to clone template"
+		record["history"] = []interface{}{
+			map[string]interface{}{
+				"displayName": "Provision",
+				"status":      "failed",
+				"events": []interface{}{
+					map[string]interface{}{"processType": map[string]interface{}{"name": "clone"}, "status": "failed", "error": "template ubuntu-22 not found"},
+				},
+			},
+		}
+	})
+	return &id
+}
+
+func TestResourceMorpheusInstance_failedProvision(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	id := testMockFailedProvision(s)
+	meta := testProviderMeta(t, s)
+
+	err := testResourceApplyError(t, meta, "morpheus_instance", nil, testInstanceConfig(s, "created by terraform"))
+	for _, expected := range []string{"is failed: Failed to clone template", "Provision: failed", "clone: failed - template ubuntu-22 not found"} {
+		if !strings.Contains(err, expected) {
+			t.Fatalf("expected error to contain %q, got %q", expected, err)
+		}
+	}
+	// the failed instance is kept for terraform to taint
+	if s.record("/api/instances", *id) == nil {
+		t.Fatal("expected the failed instance to be kept")
+	}
+}
+
+func TestResourceMorpheusInstance_deleteOnFailure(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	id := testMockFailedProvision(s)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["delete_on_failure"] = true
+
+	if err := testResourceApplyError(t, meta, "morpheus_instance", nil, config); !strings.Contains(err, "Failed to clone template") {
+		t.Fatalf("expected the provisioning error, got %q", err)
+	}
+	if s.record("/api/instances", *id) != nil {
+		t.Fatal("expected the failed instance to be deleted")
+	}
+}
+
+func TestResourceMorpheusInstance_powerState(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["power_state"] = "stopped"
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+	if len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/stop")) != 1 {
+		t.Fatal("expected the new instance to be stopped")
+	}
+	if state.Attributes["power_state"] != "stopped" {
+		t.Fatalf("expected power_state stopped, got %q", state.Attributes["power_state"])
+	}
+
+	config["power_state"] = "running"
+	state = testResourceApply(t, meta, "morpheus_instance", state, config)
+	if len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/start")) != 1 {
+		t.Fatal("expected the instance to be started")
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+
+	// powering the instance off outside of terraform is reported as drift
+	s.record("/api/instances", toInt64(state.ID))["status"] = "stopped"
+	state = testResourceRefresh(t, meta, "morpheus_instance", state)
+	if state.Attributes["power_state"] != "stopped" {
+		t.Fatalf("expected power_state stopped after refresh, got %q", state.Attributes["power_state"])
+	}
+	state = testResourceApply(t, meta, "morpheus_instance", state, config)
+	if len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/start")) != 2 {
+		t.Fatal("expected the instance to be started again")
+	}
+}
+
+func TestResourceMorpheusInstance_resume(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["power_state"] = "suspended"
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+	config["power_state"] = "running"
+	state = testResourceApply(t, meta, "morpheus_instance", state, config)
+	if len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/resume")) != 1 || len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/start")) != 0 {
+		t.Fatal("expected the suspended instance to be resumed")
+	}
+
+	// an instance ending up in another state fails without waiting for the timeout
+	s.addAction("/api/instances", "stop", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		record["status"] = "failed"
+		return 200, map[string]interface{}{"success": true}
+	})
+	config["power_state"] = "stopped"
+	if err := testResourceApplyError(t, meta, "morpheus_instance", state, config); !strings.Contains(err, "unexpected state 'failed'") {
+		t.Fatalf("expected the unexpected state to fail the apply, got %q", err)
+	}
+}
+
+func TestResourceMorpheusInstance_powerStateWarning(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["power_state"] = "stopped"
+	s.addAction("/api/instances", "stop", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		return 400, map[string]interface{}{"success": false, "msg": "agent not responding"}
+	})
+
+	// the provisioned instance is kept rather than tainted
+	r := testResource(t, "morpheus_instance")
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	state, diags := r.Apply(context.Background(), nil, diff, meta)
+	if diags.HasError() || len(diags) != 1 || !strings.Contains(diags[0].Detail, "agent not responding") {
+		t.Fatalf("expected a warning about the power state, got %v", diags)
+	}
+	if state == nil || state.ID == "" || state.Attributes["power_state"] != "running" {
+		t.Fatalf("expected the running instance in state, got %#v", state)
+	}
+
+	// the next plan powers the instance off in place
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() || diff.Attributes["power_state"] == nil || diff.Attributes["power_state"].New != "stopped" {
+		t.Fatalf("expected an in place power_state change, got %#v", diff)
+	}
+}
+
+func TestResourceMorpheusInstance_runtimeAttributes(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+	record := s.record("/api/instances", toInt64(state.ID))
+	server := fmt.Sprint(record["servers"].([]interface{})[0])
+	expected := map[string]string{
+		"status":             "running",
+		"hostname":           config["name"].(string),
+		"primary_ip_address": "10.0.0.100",
+		"ip_addresses.#":     "1",
+		"ip_addresses.0":     "10.0.0.100",
+		"server_ids.#":       "1",
+		"server_ids.0":       server,
+		"external_id":        "vm-" + state.ID,
+		"date_created":       "2023-03-01T12:00:00Z",
+	}
+	for k, v := range expected {
+		if state.Attributes[k] != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, state.Attributes[k])
+		}
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+}
+
+func TestResourceMorpheusInstance_maskedEnvironmentVariable(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testInstanceConfig(s, "created by terraform")
+	config["evar"] = []interface{}{
+		map[string]interface{}{"name": "password", "value": "s3cret", "export": false, "masked": true},
+	}
+	state := testResourceApply(t, meta, "morpheus_instance", nil, config)
+
+	// the API only returns masked values masked
+	s.record("/api/instances", toInt64(state.ID))["evars"] = []interface{}{
+		map[string]interface{}{"name": "password", "value": "************", "export": false, "masked": true},
+	}
+	state = testResourceRefresh(t, meta, "morpheus_instance", state)
+	if state.Attributes["evar.0.value"] != "s3cret" {
+		t.Fatalf("expected the applied value to be kept, got %q", state.Attributes["evar.0.value"])
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_instance", state, config)
+
+	config["evar"] = []interface{}{
+		map[string]interface{}{"name": "password", "value": "n3w", "export": false, "masked": true},
+	}
+	diff, err := testResource(t, "morpheus_instance").Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning morpheus_instance: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatal("expected changing a masked value to replace the instance")
+	}
+}
+
+func TestResourceMorpheusInstance_disappears(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_instance", "/api/instances", testInstanceConfig(s, "created by terraform"))
+}
+
+// testFastInstanceProvision stops new instances from waiting minutes
+// before checking on their provisioning.
+func testFastInstanceProvision(t *testing.T) {
+	delay, interval := instanceProvisionDelay, instanceProvisionPollInterval
+	instanceProvisionDelay, instanceProvisionPollInterval = 0, 10*time.Millisecond
+	t.Cleanup(func() {
+		instanceProvisionDelay, instanceProvisionPollInterval = delay, interval
+	})
+}
+
+// testMockInstances makes the mock server build instances the way the API
+// does from the settings sent next to the instance payload.
+func testMockInstances(s *mockServer) {
+	s.addSaveHook("/api/instances", func(record map[string]interface{}) {
+		if site, ok := record["site"].(map[string]interface{}); ok {
+			record["group"] = map[string]interface{}{"id": site["id"]}
+		}
+	})
+	s.addCreateHook("/api/instances", func(record map[string]interface{}, body map[string]interface{}) {
+		record["cloud"] = map[string]interface{}{"id": body["zoneId"]}
+		for _, c := range s.collections {
+			if c.path != "/api/library/instance-types" {
+				continue
+			}
+			for id, instanceType := range c.records {
+				if instanceType["code"] == record["type"] {
+					record["instanceType"] = map[string]interface{}{"id": id, "code": instanceType["code"]}
+				}
+			}
+		}
+		config, _ := body["config"].(map[string]interface{})
+		if config == nil {
+			config = make(map[string]interface{})
+		}
+		record["config"] = config
+		for from, to := range map[string]string{
+			"tags":              "tags",
+			"labels":            "labels",
+			"evars":             "evars",
+			"volumes":           "volumes",
+			"networkInterfaces": "interfaces",
+		} {
+			if v, ok := body[from]; ok {
+				record[to] = v
+			}
+		}
+		mockInstanceServicePlanOptions(record, body)
+		s.mockInstanceDevices(record)
+		s.mockInstanceServer(record)
+	})
+	s.addAction("/api/instances", "resize", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		body = copyMockRecord(body)
+		if instance, ok := body["instance"].(map[string]interface{}); ok && instance["plan"] != nil {
+			record["plan"] = instance["plan"]
+		}
+		if volumes, ok := body["volumes"]; ok {
+			record["volumes"] = volumes
+		}
+		if interfaces, ok := body["networkInterfaces"]; ok {
+			record["interfaces"] = interfaces
+		}
+		mockInstanceServicePlanOptions(record, body)
+		s.mockInstanceDevices(record)
+		return 200, map[string]interface{}{"success": true, "instance": record}
+	})
+	s.addAction("/api/instances", "history", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		processes, _ := record["history"].([]interface{})
+		return 200, map[string]interface{}{"processes": processes}
+	})
+	for action, status := range map[string]string{"start": "running", "stop": "stopped", "suspend": "suspended", "resume": "running"} {
+		status := status
+		s.addAction("/api/instances", action, func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+			record["status"] = status
+			return 200, map[string]interface{}{"success": true}
+		})
+	}
+}
+
+// mockInstanceServer adds the virtual machine of a new instance to the
+// servers collection, with an address assigned to each network interface.
+func (s *mockServer) mockInstanceServer(record map[string]interface{}) {
+	var serverInterfaces []interface{}
+	interfaces, _ := record["interfaces"].([]interface{})
+	for i, networkInterface := range interfaces {
+		networkInterface := networkInterface.(map[string]interface{})
+		ip, _ := networkInterface["ipAddress"].(string)
+		if ip == "" {
+			ip = fmt.Sprintf("10.0.0.%d", 100+i)
+		}
+		serverInterfaces = append(serverInterfaces, map[string]interface{}{"network": networkInterface["network"], "ipAddress": ip})
+	}
+	server := map[string]interface{}{
+		"name":       record["name"],
+		"externalId": fmt.Sprintf("vm-%d", record["id"]),
+		"interfaces": serverInterfaces,
+	}
+	for _, c := range s.collections {
+		if c.path == "/api/servers" {
+			record["servers"] = []interface{}{s.insert(c, server)}
+		}
+	}
+	record["hostName"] = record["name"]
+	record["dateCreated"] = "2023-03-01T12:00:00Z"
+	if len(serverInterfaces) > 0 {
+		record["connectionInfo"] = []interface{}{
+			map[string]interface{}{"ip": serverInterfaces[0].(map[string]interface{})["ipAddress"], "port": 22},
+		}
+	}
+}
+
+// mockInstanceDevices assigns ids to the new volumes and network interfaces
+// of an instance record.
+func (s *mockServer) mockInstanceDevices(record map[string]interface{}) {
+	for _, key := range []string{"volumes", "interfaces"} {
+		devices, _ := record[key].([]interface{})
+		for _, device := range devices {
+			device := device.(map[string]interface{})
+			if id, ok := device["id"].(float64); !ok || id < 0 {
+				s.nextID++
+				device["id"] = float64(s.nextID)
+			}
+		}
+	}
+}
+
+// mockInstanceServicePlanOptions applies the custom cores and memory sent
+// with a request to an instance record.
+func mockInstanceServicePlanOptions(record map[string]interface{}, body map[string]interface{}) {
+	servicePlanOptions, _ := body["servicePlanOptions"].(map[string]interface{})
+	for k, v := range servicePlanOptions {
+		record[k] = v
+	}
+}
@@ -90,7 +90,7 @@ func resourceJavaScriptTask() *schema.Resource {
 }
 
 func resourceJavaScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -136,7 +136,7 @@ func resourceJavaScriptTaskCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceJavaScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -159,7 +159,9 @@ func resourceJavaScriptTaskRead(ctx context.Context, d *schema.ResourceData, met
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -182,7 +184,7 @@ func resourceJavaScriptTaskRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceJavaScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	taskOptions := make(map[string]interface{})
@@ -223,7 +225,7 @@ func resourceJavaScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceJavaScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -234,7 +236,8 @@ func resourceJavaScriptTaskDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -86,7 +86,7 @@ func resourceKubernetesAppBlueprint() *schema.Resource {
 }
 
 func resourceKubernetesAppBlueprintCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -165,7 +165,7 @@ func resourceKubernetesAppBlueprintCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesAppBlueprintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -188,7 +188,9 @@ func resourceKubernetesAppBlueprintRead(ctx context.Context, d *schema.ResourceD
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -232,7 +234,7 @@ func resourceKubernetesAppBlueprintRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceKubernetesAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	blueprint_type := "kubernetes"
@@ -306,7 +308,7 @@ func resourceKubernetesAppBlueprintUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesAppBlueprintDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -317,7 +319,8 @@ func resourceKubernetesAppBlueprintDelete(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
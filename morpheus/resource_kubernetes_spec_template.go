@@ -66,7 +66,7 @@ func resourceKubernetesSpecTemplate() *schema.Resource {
 }
 
 func resourceKubernetesSpecTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -120,7 +120,7 @@ func resourceKubernetesSpecTemplateCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesSpecTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -143,7 +143,9 @@ func resourceKubernetesSpecTemplateRead(ctx context.Context, d *schema.ResourceD
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -176,7 +178,7 @@ func resourceKubernetesSpecTemplateRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceKubernetesSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -225,7 +227,7 @@ func resourceKubernetesSpecTemplateUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesSpecTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -236,7 +238,8 @@ func resourceKubernetesSpecTemplateDelete(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
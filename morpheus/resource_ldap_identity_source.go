@@ -0,0 +1,131 @@
+package morpheus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var ldapIdentitySource = identitySourceType{
+	code:        "ldap",
+	title:       "LDAP",
+	description: "LDAP",
+	groupPrefix: "group",
+	groupName:   "The name of the LDAP group to map to",
+	groupFqn:    "The fully qualified name of the LDAP group to map to (i.e. - cn=admins,ou=groups,dc=example,dc=com)",
+	settings: []identitySourceSetting{
+		{attribute: "url", field: "url"},
+		{attribute: "binding_username", field: "bindingUsername"},
+		{attribute: "binding_password", field: "bindingPassword", secret: true},
+		{attribute: "user_fqn_expression", field: "userFqnExpression"},
+		{attribute: "required_group_fqn", field: "requiredRoleFqn"},
+		{attribute: "username_attribute", field: "usernameAttribute"},
+		{attribute: "common_name_attribute", field: "commonNameAttribute"},
+		{attribute: "first_name_attribute", field: "firstNameAttribute"},
+		{attribute: "last_name_attribute", field: "lastNameAttribute"},
+		{attribute: "email_attribute", field: "emailAttribute"},
+		{attribute: "unique_member_attribute", field: "uniqueMemberAttribute"},
+		{attribute: "member_of_attribute", field: "memberOfAttribute"},
+	},
+}
+
+func resourceLdapIdentitySource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an LDAP identity source resource",
+		CreateContext: resourceLdapIdentitySourceCreate,
+		ReadContext:   resourceLdapIdentitySourceRead,
+		UpdateContext: resourceLdapIdentitySourceUpdate,
+		DeleteContext: resourceIdentitySourceDelete,
+		CustomizeDiff: customizeIdentitySourceRoleMappingDiff(ldapIdentitySource.groupPrefix),
+
+		Schema: identitySourceSchema(ldapIdentitySource, map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the LDAP server (i.e. - ldaps://ldap.example.com:636)",
+				Required:    true,
+			},
+			"binding_username": {
+				Type:        schema.TypeString,
+				Description: "The distinguished name of the account used to bind to the LDAP server",
+				Required:    true,
+			},
+			"binding_password": {
+				Type:             schema.TypeString,
+				Description:      "The password of the account used to bind to the LDAP server",
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"user_fqn_expression": {
+				Type:        schema.TypeString,
+				Description: "The expression used to build the distinguished name of a user from their username (i.e. - uid=$username,ou=users,dc=example,dc=com)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"required_group_fqn": {
+				Type:        schema.TypeString,
+				Description: "The distinguished name of the LDAP group users must be in to access Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"username_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute holding the username of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"common_name_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute holding the common name of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"first_name_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute holding the first name of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"last_name_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute holding the last name of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"email_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute holding the email address of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"unique_member_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute of a group listing its members",
+				Optional:    true,
+				Computed:    true,
+			},
+			"member_of_attribute": {
+				Type:        schema.TypeString,
+				Description: "The LDAP attribute of a user listing the groups they are a member of",
+				Optional:    true,
+				Computed:    true,
+			},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceLdapIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createIdentitySource(ctx, d, meta, ldapIdentitySource)
+}
+
+func resourceLdapIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIdentitySource(ctx, d, meta, ldapIdentitySource)
+}
+
+func resourceLdapIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateIdentitySource(ctx, d, meta, ldapIdentitySource)
+}
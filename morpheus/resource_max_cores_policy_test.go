@@ -0,0 +1,21 @@
+package morpheus
+
+import "testing"
+
+func TestResourceMaxCoresPolicy_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_max_cores_policy", "/api/policies",
+		map[string]interface{}{
+			"name":      "tf_example_max_cores_policy",
+			"enabled":   true,
+			"max_cores": 10,
+			"scope":     "global",
+		},
+		map[string]interface{}{
+			"name":      "tf_example_max_cores_policy",
+			"enabled":   true,
+			"max_cores": 20,
+			"scope":     "global",
+		},
+	)
+}
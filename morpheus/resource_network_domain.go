@@ -77,9 +77,10 @@ func resourceNetworkDomain() *schema.Resource {
 				Default:      "private",
 			},
 			"tenant_id": {
-				Description: "The tenant to assign the network domain",
+				Description: "The tenant to assign the network domain, defaults to the tenant the provider is scoped to",
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 			},
 		},
 		Importer: &schema.ResourceImporter{
@@ -89,7 +90,7 @@ func resourceNetworkDomain() *schema.Resource {
 }
 
 func resourceNetworkDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -98,16 +99,22 @@ func resourceNetworkDomainCreate(ctx context.Context, d *schema.ResourceData, me
 	description := d.Get("description").(string)
 	// domainController := d.Get("domain_controller").(bool) // .(bool)
 	//active := d.Get("active").(bool)
+	networkDomain := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"publicZone":  d.Get("public_zone").(bool),
+		"visibility":  d.Get("visibility").(string),
+		// "domainController": domainController,
+		// "active":active,
+	}
+	if tenantID := resourceTenantID(d, meta); tenantID != 0 {
+		networkDomain["account"] = map[string]interface{}{
+			"id": tenantID,
+		}
+	}
 	req := &morpheus.Request{
 		Body: map[string]interface{}{
-			"networkDomain": map[string]interface{}{
-				"name":        name,
-				"description": description,
-				"publicZone":  d.Get("public_zone").(bool),
-				"visibility":  d.Get("visibility").(string),
-				// "domainController": domainController,
-				// "active":active,
-			},
+			"networkDomain": networkDomain,
 		},
 	}
 	resp, err := client.CreateNetworkDomain(req)
@@ -118,15 +125,14 @@ func resourceNetworkDomainCreate(ctx context.Context, d *schema.ResourceData, me
 	log.Printf("API RESPONSE: %s", resp)
 
 	result := resp.Result.(*morpheus.CreateNetworkDomainResult)
-	networkDomain := result.NetworkDomain
 	// Successfully created resource, now set id
-	d.SetId(int64ToString(networkDomain.ID))
+	d.SetId(int64ToString(result.NetworkDomain.ID))
 	resourceNetworkDomainRead(ctx, d, meta)
 	return diags
 }
 
 func resourceNetworkDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -148,7 +154,9 @@ func resourceNetworkDomainRead(ctx context.Context, d *schema.ResourceData, meta
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -167,6 +175,9 @@ func resourceNetworkDomainRead(ctx context.Context, d *schema.ResourceData, meta
 		d.Set("public_zone", networkDomain.PublicZone)
 		d.Set("domain_controller", networkDomain.DomainController)
 		d.Set("visibility", networkDomain.Visibility)
+		if networkDomain.Account != nil {
+			d.Set("tenant_id", networkDomain.Account.ID)
+		}
 		// d.Set("fqdn", networkDomain.Fqdn)
 	} else {
 		return diag.Errorf("NetworkDomain not found in response data.") // should not happen
@@ -176,7 +187,7 @@ func resourceNetworkDomainRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceNetworkDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -184,15 +195,21 @@ func resourceNetworkDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 	// domainController := d.Get("domain_controller").(bool) // .(bool)
 	//active := d.Get("active").(bool)
 
+	networkDomain := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		// "publicZone": publicZone,
+		// "domainController": domainController,
+		//"active":active,
+	}
+	if tenantID := d.Get("tenant_id").(int); tenantID != 0 {
+		networkDomain["account"] = map[string]interface{}{
+			"id": tenantID,
+		}
+	}
 	req := &morpheus.Request{
 		Body: map[string]interface{}{
-			"networkDomain": map[string]interface{}{
-				"name":        name,
-				"description": description,
-				// "publicZone": publicZone,
-				// "domainController": domainController,
-				//"active":active,
-			},
+			"networkDomain": networkDomain,
 		},
 	}
 	resp, err := client.UpdateNetworkDomain(toInt64(id), req)
@@ -202,15 +219,14 @@ func resourceNetworkDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 	}
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.UpdateNetworkDomainResult)
-	networkDomain := result.NetworkDomain
 	// Successfully updated resource, now set id
 	// err, it should not have changed though..
-	d.SetId(int64ToString(networkDomain.ID))
+	d.SetId(int64ToString(result.NetworkDomain.ID))
 	return resourceNetworkDomainRead(ctx, d, meta)
 }
 
 func resourceNetworkDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -221,7 +237,8 @@ func resourceNetworkDomainDelete(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
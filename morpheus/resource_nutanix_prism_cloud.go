@@ -0,0 +1,409 @@
+package morpheus
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNutanixPrismCloud() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus Nutanix Prism Central cloud resource.",
+		CreateContext: resourceNutanixPrismCloudCreate,
+		ReadContext:   resourceNutanixPrismCloudRead,
+		UpdateContext: resourceNutanixPrismCloudUpdate,
+		DeleteContext: resourceNutanixPrismCloudDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the cloud",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the cloud integration",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"code": {
+				Description: "Optional code for use with policies",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"location": {
+				Description: "Optional location for the cloud",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"visibility": {
+				Description:  "Determines whether the cloud is visible in sub-tenants or not",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public", ""}, false),
+				Default:      "private",
+			},
+			"tenant_id": {
+				Description: "The id of the morpheus tenant the cloud is assigned to, defaults to the tenant the provider is scoped to",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "Determines whether the cloud is active or not",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"automatically_power_on_vms": {
+				Description: "Determines whether to automatically power on cloud virtual machines",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the Prism Central API (i.e. - https://prism.example.com:9440)",
+				Required:    true,
+			},
+			"credential_id": {
+				Description:   "The ID of the credential store entry used for authentication",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username", "password"},
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Description:  "The username of the Prism Central account used for authentication",
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"password"},
+			},
+			"password": {
+				Type:             schema.TypeString,
+				Description:      "The password of the Prism Central account used for authentication",
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+				RequiredWith:     []string{"username"},
+			},
+			"ignore_ssl_errors": {
+				Type:        schema.TypeBool,
+				Description: "Whether to skip verification of the SSL certificate presented by Prism Central",
+				Optional:    true,
+				Default:     false,
+			},
+			"import_existing_vms": {
+				Type:        schema.TypeBool,
+				Description: "Whether to import existing virtual machines",
+				Optional:    true,
+				Default:     false,
+			},
+			"enable_hypervisor_console": {
+				Type:        schema.TypeBool,
+				Description: "Whether to enable VNC access to virtual machines in the cloud",
+				Optional:    true,
+				Default:     false,
+			},
+			"import_images": {
+				Type:        schema.TypeBool,
+				Description: "Whether to inventory the images available in Prism Central",
+				Optional:    true,
+				Default:     true,
+			},
+			"import_networks": {
+				Type:        schema.TypeBool,
+				Description: "Whether to inventory the networks available in Prism Central",
+				Optional:    true,
+				Default:     true,
+			},
+			"appliance_url": {
+				Type:        schema.TypeString,
+				Description: "The URL used by workloads provisioned in the cloud for interacting with the Morpheus server",
+				Optional:    true,
+				Computed:    true,
+			},
+			"time_zone": {
+				Type:        schema.TypeString,
+				Description: "The time zone for the cloud",
+				Optional:    true,
+				Computed:    true,
+			},
+			"datacenter_id": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary id used to reference the datacenter for the cloud",
+				Optional:    true,
+				Computed:    true,
+			},
+			"guidance": {
+				Type:         schema.TypeString,
+				Description:  "Whether to enable guidance recommendations on the cloud (manual, off)",
+				ValidateFunc: validation.StringInSlice([]string{"manual", "off"}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+			"costing": {
+				Type:         schema.TypeString,
+				Description:  "Whether to enable costing on the cloud (off, costing)",
+				ValidateFunc: validation.StringInSlice([]string{"off", "costing"}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+			"agent_install_mode": {
+				Type:         schema.TypeString,
+				Description:  "The method used to install the Morpheus agent on virtual machines provisioned in the cloud (ssh, cloudInit)",
+				ValidateFunc: validation.StringInSlice([]string{"ssh", "cloudInit", ""}, false),
+				Optional:     true,
+				Computed:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceNutanixPrismCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
+
+	payload := map[string]interface{}{
+		"zone": nutanixPrismCloudPayload(d, tenantID),
+	}
+
+	req := &morpheus.Request{Body: payload}
+
+	resp, err := client.CreateCloud(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateCloudResult)
+	cloudOutput := result.Cloud
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(cloudOutput.ID))
+
+	if err := waitForCloudSync(ctx, client, cloudOutput.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error creating cloud: %s", err)
+	}
+
+	resourceNutanixPrismCloudRead(ctx, d, meta)
+	return diags
+}
+
+func resourceNutanixPrismCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	name := d.Get("name").(string)
+
+	// lookup by name if we do not have an id yet
+	var resp *morpheus.Response
+	var err error
+	if id == "" && name != "" {
+		resp, err = client.FindCloudByName(name)
+	} else if id != "" {
+		resp, err = client.GetCloud(toInt64(id), &morpheus.Request{})
+	} else {
+		return diag.Errorf("Cloud cannot be read without name or id")
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetCloudResult)
+	cloud := result.Cloud
+	if cloud == nil {
+		d.SetId("")
+		return diags
+	}
+	config, err := cloudRawConfig(resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(int64ToString(cloud.ID))
+	d.Set("name", cloud.Name)
+	d.Set("code", cloud.Code)
+	d.Set("location", cloud.Location)
+	d.Set("visibility", cloud.Visibility)
+	d.Set("tenant_id", strconv.Itoa(int(cloud.AccountID)))
+	d.Set("enabled", cloud.Enabled)
+	d.Set("automatically_power_on_vms", cloud.AutoRecoverPowerState)
+	d.Set("api_url", cloud.Config.APIUrl)
+	d.Set("credential_id", cloud.Credential.ID)
+	d.Set("username", cloud.Config.Username)
+	d.Set("password", cloud.Config.PasswordHash)
+	d.Set("ignore_ssl_errors", cloudConfigString(config, "ignoreSsl") == "on")
+	d.Set("import_existing_vms", cloud.Config.ImportExisting == "on")
+	d.Set("enable_hypervisor_console", cloud.Config.EnableVNC == "on")
+	d.Set("import_images", cloudConfigString(config, "importImages") != "off")
+	d.Set("import_networks", cloudConfigString(config, "importNetworks") != "off")
+	d.Set("appliance_url", cloud.Config.ApplianceUrl)
+	d.Set("time_zone", cloud.TimeZone)
+	d.Set("datacenter_id", cloud.Config.DatacenterName)
+	d.Set("guidance", cloud.GuidanceMode)
+	d.Set("costing", cloud.CostingMode)
+	d.Set("agent_install_mode", cloud.AgentMode)
+	return diags
+}
+
+func resourceNutanixPrismCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	payload := map[string]interface{}{
+		"zone": nutanixPrismCloudPayload(d, d.Get("tenant_id").(string)),
+	}
+
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.UpdateCloud(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateCloudResult)
+	cloudOutput := result.Cloud
+	// Successfully updated resource, now set id
+	d.SetId(int64ToString(cloudOutput.ID))
+	return resourceNutanixPrismCloudRead(ctx, d, meta)
+}
+
+func resourceNutanixPrismCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteCloud(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// nutanixPrismCloudPayload builds the zone payload shared by create and update.
+func nutanixPrismCloudPayload(d *schema.ResourceData, tenantID string) map[string]interface{} {
+	cloud := make(map[string]interface{})
+	cloud["name"] = d.Get("name").(string)
+	cloud["code"] = d.Get("code").(string)
+	cloud["location"] = d.Get("location").(string)
+	cloud["visibility"] = d.Get("visibility").(string)
+
+	account := make(map[string]interface{})
+	account["id"] = tenantID
+	cloud["account"] = account
+	cloud["accountId"] = tenantID
+
+	cloud["enabled"] = d.Get("enabled").(bool)
+	cloud["autoRecoverPowerState"] = d.Get("automatically_power_on_vms").(bool)
+
+	config := make(map[string]interface{})
+	config["apiUrl"] = d.Get("api_url").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "username-password"
+		credential["id"] = d.Get("credential_id").(int)
+		cloud["credential"] = credential
+	} else {
+		credential := make(map[string]interface{})
+		credential["type"] = "local"
+		cloud["credential"] = credential
+		config["username"] = d.Get("username").(string)
+		// the state only holds the hash of the password
+		if d.IsNewResource() || d.HasChange("password") {
+			config["password"] = d.Get("password").(string)
+		}
+	}
+
+	if d.Get("ignore_ssl_errors").(bool) {
+		config["ignoreSsl"] = "on"
+	} else {
+		config["ignoreSsl"] = "off"
+	}
+
+	if d.Get("import_existing_vms").(bool) {
+		config["importExisting"] = "on"
+	} else {
+		config["importExisting"] = "off"
+	}
+
+	if d.Get("enable_hypervisor_console").(bool) {
+		config["enableVnc"] = "on"
+	} else {
+		config["enableVnc"] = "off"
+	}
+
+	if d.Get("import_images").(bool) {
+		config["importImages"] = "on"
+	} else {
+		config["importImages"] = "off"
+	}
+
+	if d.Get("import_networks").(bool) {
+		config["importNetworks"] = "on"
+	} else {
+		config["importNetworks"] = "off"
+	}
+
+	config["applianceUrl"] = d.Get("appliance_url")
+	cloud["timezone"] = d.Get("time_zone").(string)
+	config["datacenterName"] = d.Get("datacenter_id")
+	cloud["guidanceMode"] = d.Get("guidance").(string)
+	cloud["costingMode"] = d.Get("costing").(string)
+	cloud["agentMode"] = d.Get("agent_install_mode").(string)
+
+	cloud["config"] = config
+
+	cloudType := make(map[string]interface{})
+	cloudType["code"] = "nutanix-prism-cloud"
+	cloud["zoneType"] = cloudType
+
+	return cloud
+}
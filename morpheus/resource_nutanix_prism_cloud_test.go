@@ -0,0 +1,78 @@
+package morpheus
+
+import (
+	"testing"
+)
+
+func testNutanixPrismCloudConfig(location string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                      "tfprism",
+		"code":                      "tfprism",
+		"location":                  location,
+		"api_url":                   "https://prism.example.com:9440",
+		"username":                  "admin",
+		"password":                  "Password123",
+		"import_existing_vms":       true,
+		"enable_hypervisor_console": true,
+		"import_images":             false,
+		"agent_install_mode":        "ssh",
+	}
+}
+
+func TestResourceMorpheusNutanixPrismCloud_lifecycle(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	testResourceLifecycle(t, s, "morpheus_nutanix_prism_cloud", "/api/zones", testNutanixPrismCloudConfig("denver"), testNutanixPrismCloudConfig("boulder"))
+}
+
+func TestResourceMorpheusNutanixPrismCloud_payload(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_nutanix_prism_cloud", nil, testNutanixPrismCloudConfig("denver"))
+	zone := s.requestsFor("POST", "/api/zones")[0].Body["zone"].(map[string]interface{})
+	if zone["zoneType"].(map[string]interface{})["code"] != "nutanix-prism-cloud" {
+		t.Fatalf("expected zoneType nutanix-prism-cloud, got %v", zone["zoneType"])
+	}
+	config := zone["config"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"apiUrl":         "https://prism.example.com:9440",
+		"username":       "admin",
+		"password":       "Password123",
+		"ignoreSsl":      "off",
+		"importExisting": "on",
+		"enableVnc":      "on",
+		"importImages":   "off",
+		"importNetworks": "on",
+	}
+	for k, v := range expected {
+		if config[k] != v {
+			t.Fatalf("expected config %s to be %v, got %v", k, v, config[k])
+		}
+	}
+
+	state = testResourceRefresh(t, meta, "morpheus_nutanix_prism_cloud", state)
+	if state.Attributes["import_existing_vms"] != "true" || state.Attributes["enable_hypervisor_console"] != "true" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	// the password is only sent again when it changes
+	testResourceApply(t, meta, "morpheus_nutanix_prism_cloud", state, testNutanixPrismCloudConfig("boulder"))
+	config = s.requestsFor("PUT", "/api/zones/"+state.ID)[0].Body["zone"].(map[string]interface{})["config"].(map[string]interface{})
+	if _, ok := config["password"]; ok {
+		t.Fatal("expected unchanged password not to be sent")
+	}
+}
+
+func TestResourceMorpheusNutanixPrismCloud_syncFailure(t *testing.T) {
+	testCloudSyncFailure(t, "morpheus_nutanix_prism_cloud", testNutanixPrismCloudConfig("denver"))
+}
+
+func TestResourceMorpheusNutanixPrismCloud_disappears(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_nutanix_prism_cloud", "/api/zones", testNutanixPrismCloudConfig("denver"))
+}
@@ -0,0 +1,69 @@
+package morpheus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var oktaIdentitySource = identitySourceType{
+	code:        "okta",
+	title:       "Okta",
+	description: "Okta",
+	groupPrefix: "group",
+	groupName:   "The name of the Okta group to map to",
+	groupFqn:    "The name of the Okta group to map to as returned by Okta",
+	settings: []identitySourceSetting{
+		{attribute: "okta_url", field: "url"},
+		{attribute: "administrator_api_token", field: "administratorAPIToken", secret: true},
+		{attribute: "required_group", field: "requiredGroup"},
+	},
+}
+
+func resourceOktaIdentitySource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an Okta identity source resource",
+		CreateContext: resourceOktaIdentitySourceCreate,
+		ReadContext:   resourceOktaIdentitySourceRead,
+		UpdateContext: resourceOktaIdentitySourceUpdate,
+		DeleteContext: resourceIdentitySourceDelete,
+		CustomizeDiff: customizeIdentitySourceRoleMappingDiff(oktaIdentitySource.groupPrefix),
+
+		Schema: identitySourceSchema(oktaIdentitySource, map[string]*schema.Schema{
+			"okta_url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the Okta organization (i.e. - https://example.okta.com)",
+				Required:    true,
+			},
+			"administrator_api_token": {
+				Type:             schema.TypeString,
+				Description:      "The Okta API token used to look up users and groups",
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"required_group": {
+				Type:        schema.TypeString,
+				Description: "The Okta group users must be in to access Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceOktaIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createIdentitySource(ctx, d, meta, oktaIdentitySource)
+}
+
+func resourceOktaIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIdentitySource(ctx, d, meta, oktaIdentitySource)
+}
+
+func resourceOktaIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateIdentitySource(ctx, d, meta, oktaIdentitySource)
+}
@@ -0,0 +1,101 @@
+package morpheus
+
+import (
+	"testing"
+)
+
+func testOpenStackCloudConfig(location string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              "tfopenstack",
+		"code":              "tfopenstack",
+		"location":          location,
+		"identity_url":      "https://openstack.example.com:5000/v3",
+		"domain_id":         "morpheus",
+		"project_name":      "demo",
+		"region":            "RegionOne",
+		"username":          "admin",
+		"password":          "Password123",
+		"ignore_ssl_errors": true,
+		"import_networks":   false,
+		"costing":           "costing",
+		"guidance":          "off",
+	}
+}
+
+func TestResourceMorpheusOpenStackCloud_lifecycle(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	testResourceLifecycle(t, s, "morpheus_openstack_cloud", "/api/zones", testOpenStackCloudConfig("denver"), testOpenStackCloudConfig("boulder"))
+}
+
+func TestResourceMorpheusOpenStackCloud_payload(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testMockCloudSecrets(s)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_openstack_cloud", nil, testOpenStackCloudConfig("denver"))
+	zone := s.requestsFor("POST", "/api/zones")[0].Body["zone"].(map[string]interface{})
+	if zone["zoneType"].(map[string]interface{})["code"] != "openstack" {
+		t.Fatalf("expected zoneType openstack, got %v", zone["zoneType"])
+	}
+	if zone["regionCode"] != "RegionOne" {
+		t.Fatalf("expected region RegionOne, got %v", zone["regionCode"])
+	}
+	config := zone["config"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"identityApi":    "https://openstack.example.com:5000/v3",
+		"domainId":       "morpheus",
+		"projectName":    "demo",
+		"username":       "admin",
+		"password":       "Password123",
+		"ignoreSsl":      "on",
+		"importExisting": "off",
+		"importImages":   "on",
+		"importNetworks": "off",
+	}
+	for k, v := range expected {
+		if config[k] != v {
+			t.Fatalf("expected config %s to be %v, got %v", k, v, config[k])
+		}
+	}
+
+	// the password is only sent again when it changes
+	testResourceApply(t, meta, "morpheus_openstack_cloud", state, testOpenStackCloudConfig("boulder"))
+	config = s.requestsFor("PUT", "/api/zones/"+state.ID)[0].Body["zone"].(map[string]interface{})["config"].(map[string]interface{})
+	if _, ok := config["password"]; ok {
+		t.Fatal("expected unchanged password not to be sent")
+	}
+}
+
+func TestResourceMorpheusOpenStackCloud_credential(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+
+	config := testOpenStackCloudConfig("denver")
+	delete(config, "username")
+	delete(config, "password")
+	config["credential_id"] = 5
+	testResourceApply(t, meta, "morpheus_openstack_cloud", nil, config)
+
+	zone := s.requestsFor("POST", "/api/zones")[0].Body["zone"].(map[string]interface{})
+	credential := zone["credential"].(map[string]interface{})
+	if credential["type"] != "username-password" || credential["id"] != float64(5) {
+		t.Fatalf("expected credential store entry 5, got %v", credential)
+	}
+	if _, ok := zone["config"].(map[string]interface{})["password"]; ok {
+		t.Fatal("expected no password to be sent with a credential store entry")
+	}
+}
+
+func TestResourceMorpheusOpenStackCloud_syncFailure(t *testing.T) {
+	testCloudSyncFailure(t, "morpheus_openstack_cloud", testOpenStackCloudConfig("denver"))
+}
+
+func TestResourceMorpheusOpenStackCloud_disappears(t *testing.T) {
+	testFastCloudSync(t)
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_openstack_cloud", "/api/zones", testOpenStackCloudConfig("denver"))
+}
@@ -144,7 +144,7 @@ func resourcePowerShellScriptTask() *schema.Resource {
 }
 
 func resourcePowerShellScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -221,7 +221,7 @@ func resourcePowerShellScriptTaskCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourcePowerShellScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -244,7 +244,9 @@ func resourcePowerShellScriptTaskRead(ctx context.Context, d *schema.ResourceDat
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -281,7 +283,7 @@ func resourcePowerShellScriptTaskRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourcePowerShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -352,7 +354,7 @@ func resourcePowerShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourcePowerShellScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -363,7 +365,8 @@ func resourcePowerShellScriptTaskDelete(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
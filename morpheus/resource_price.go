@@ -39,7 +39,7 @@ func resourcePrice() *schema.Resource {
 			},
 			"tenant_id": {
 				Type:        schema.TypeInt,
-				Description: "The id of the tenant to assign the price to",
+				Description: "The id of the tenant to assign the price to, defaults to the tenant the provider is scoped to",
 				Optional:    true,
 				ForceNew:    true,
 			},
@@ -134,7 +134,7 @@ func resourcePrice() *schema.Resource {
 }
 
 func resourcePriceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -162,9 +162,10 @@ func resourcePriceCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		price["customPrice"] = d.Get("custom_price")
 	}
 
-	if d.Get("tenant_id") != nil {
+	// default to the tenant the provider is scoped to
+	if tenantID := resourceTenantID(d, meta); tenantID != 0 {
 		price["account"] = map[string]interface{}{
-			"id": d.Get("tenant_id"),
+			"id": tenantID,
 		}
 	}
 
@@ -209,7 +210,7 @@ func resourcePriceCreate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourcePriceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -230,7 +231,9 @@ func resourcePriceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -290,7 +293,7 @@ func resourcePriceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 }
 
 func resourcePriceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	price := make(map[string]interface{})
@@ -354,7 +357,7 @@ func resourcePriceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourcePriceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -365,7 +368,8 @@ func resourcePriceDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
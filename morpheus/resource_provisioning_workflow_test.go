@@ -0,0 +1,23 @@
+package morpheus
+
+import "testing"
+
+func TestResourceProvisioningWorkflow_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	taskID := s.seed("/api/tasks", map[string]interface{}{"name": "tf_example_task"})
+	testResourceLifecycle(t, s, "morpheus_provisioning_workflow", "/api/task-sets",
+		map[string]interface{}{
+			"name":        "tf_example_provisioning_workflow",
+			"description": "Terraform provisioning workflow example",
+			"platform":    "linux",
+			"visibility":  "private",
+			"task": []interface{}{
+				map[string]interface{}{
+					"task_id":    int(taskID),
+					"task_phase": "configure",
+				},
+			},
+		},
+		nil,
+	)
+}
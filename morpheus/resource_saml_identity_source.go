@@ -0,0 +1,149 @@
+package morpheus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var samlIdentitySource = identitySourceType{
+	code:        "saml",
+	title:       "SAML",
+	description: "SAML",
+	groupPrefix: "group",
+	groupName:   "The name of the SAML role attribute value to map to",
+	groupFqn:    "The SAML role attribute value to map to",
+	settings: []identitySourceSetting{
+		{attribute: "login_url", field: "loginUrl"},
+		{attribute: "logout_url", field: "logoutUrl"},
+		{attribute: "exclude_saml_request", field: "doNotIncludeSAMLRequest"},
+		{attribute: "signature_mode", field: "SAMLSignatureMode"},
+		{attribute: "request_x509_certificate", field: "request509Certificate"},
+		{attribute: "request_private_key", field: "requestPrivateKey", secret: true},
+		{attribute: "skip_signature_validation", field: "doNotValidateSignature"},
+		{attribute: "public_key", field: "publicKey"},
+		{attribute: "private_key", field: "privateKey", secret: true},
+		{attribute: "role_attribute_name", field: "roleAttributeName"},
+		{attribute: "required_attribute_value", field: "requiredAttributeValue"},
+		{attribute: "given_name_attribute", field: "givenNameAttribute"},
+		{attribute: "surname_attribute", field: "surnameAttribute"},
+		{attribute: "email_attribute", field: "emailAttribute"},
+	},
+}
+
+func resourceSamlIdentitySource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a SAML identity source resource",
+		CreateContext: resourceSamlIdentitySourceCreate,
+		ReadContext:   resourceSamlIdentitySourceRead,
+		UpdateContext: resourceSamlIdentitySourceUpdate,
+		DeleteContext: resourceIdentitySourceDelete,
+		CustomizeDiff: customizeIdentitySourceRoleMappingDiff(samlIdentitySource.groupPrefix),
+
+		Schema: identitySourceSchema(samlIdentitySource, map[string]*schema.Schema{
+			"login_url": {
+				Type:        schema.TypeString,
+				Description: "The SAML single sign-on URL of the identity provider",
+				Required:    true,
+			},
+			"logout_url": {
+				Type:        schema.TypeString,
+				Description: "The URL users are redirected to when they log out of Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"exclude_saml_request": {
+				Type:        schema.TypeBool,
+				Description: "Whether to leave the SAMLRequest parameter out of the login redirect",
+				Optional:    true,
+				Computed:    true,
+			},
+			"signature_mode": {
+				Type:         schema.TypeString,
+				Description:  "The method used to sign SAML requests (NoSigning, SelfSigned or CustomRSA)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"NoSigning", "SelfSigned", "CustomRSA"}, false),
+			},
+			"request_x509_certificate": {
+				Type:        schema.TypeString,
+				Description: "The X.509 certificate used to sign SAML requests when the signature mode is CustomRSA",
+				Optional:    true,
+				Computed:    true,
+			},
+			"request_private_key": {
+				Type:             schema.TypeString,
+				Description:      "The private key used to sign SAML requests when the signature mode is CustomRSA",
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"skip_signature_validation": {
+				Type:        schema.TypeBool,
+				Description: "Whether to skip the validation of the signature of SAML responses",
+				Optional:    true,
+				Computed:    true,
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Description: "The public key or certificate of the identity provider used to validate SAML responses",
+				Optional:    true,
+				Computed:    true,
+			},
+			"private_key": {
+				Type:             schema.TypeString,
+				Description:      "The private key used to decrypt encrypted SAML assertions",
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecretDiff,
+			},
+			"role_attribute_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the SAML attribute holding the roles of a user, matched against the role mappings",
+				Optional:    true,
+				Computed:    true,
+			},
+			"required_attribute_value": {
+				Type:        schema.TypeString,
+				Description: "The value the role attribute of a user must contain to access Morpheus",
+				Optional:    true,
+				Computed:    true,
+			},
+			"given_name_attribute": {
+				Type:        schema.TypeString,
+				Description: "The name of the SAML attribute holding the first name of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"surname_attribute": {
+				Type:        schema.TypeString,
+				Description: "The name of the SAML attribute holding the last name of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+			"email_attribute": {
+				Type:        schema.TypeString,
+				Description: "The name of the SAML attribute holding the email address of a user",
+				Optional:    true,
+				Computed:    true,
+			},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceSamlIdentitySourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createIdentitySource(ctx, d, meta, samlIdentitySource)
+}
+
+func resourceSamlIdentitySourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readIdentitySource(ctx, d, meta, samlIdentitySource)
+}
+
+func resourceSamlIdentitySourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateIdentitySource(ctx, d, meta, samlIdentitySource)
+}
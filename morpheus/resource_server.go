@@ -0,0 +1,363 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serversPath is the API endpoint for servers, which the SDK does not
+// cover.
+const serversPath = "/api/servers"
+
+func resourceServer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus server resource to register an existing host.",
+		CreateContext: resourceServerCreate,
+		ReadContext:   resourceServerRead,
+		UpdateContext: resourceServerUpdate,
+		DeleteContext: resourceServerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the server",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the server",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the server",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"cloud_id": {
+				Description: "The ID of the cloud to register the server in",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ip_address": {
+				Description: "The IP address or hostname used to connect to the server over SSH",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"ssh_port": {
+				Description:  "The SSH port of the server",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      22,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"ssh_username": {
+				Description: "The username used to connect to the server over SSH",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"ssh_password": {
+				Description: "The password used to connect to the server over SSH",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"key_pair_id": {
+				Description: "The ID of the key pair used to connect to the server over SSH",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"plan_id": {
+				Description: "The ID of the service plan of the server",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"labels": {
+				Description: "The list of labels to add to the server",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Description: "Tags to assign to the server",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"install_agent": {
+				Description: "Whether to install the Morpheus agent on the server, setting it back to false does not uninstall the agent",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"agent_installed": {
+				Description: "Whether the Morpheus agent is installed on the server",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the server",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"power_state": {
+				Description: "The power state of the server",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// serverDetails is a server as returned by the servers endpoint.
+type serverDetails struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SSHHost     string `json:"sshHost"`
+	SSHPort     int    `json:"sshPort"`
+	SSHUsername string `json:"sshUsername"`
+	Zone        struct {
+		ID int64 `json:"id"`
+	} `json:"zone"`
+	Plan struct {
+		ID int64 `json:"id"`
+	} `json:"plan"`
+	SSHKeyPair struct {
+		ID int64 `json:"id"`
+	} `json:"sshKeyPair"`
+	Labels         []string            `json:"labels"`
+	Tags           []map[string]string `json:"tags"`
+	AgentInstalled bool                `json:"agentInstalled"`
+	Status         string              `json:"status"`
+	PowerState     string              `json:"powerState"`
+}
+
+func resourceServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	server := serverPayload(d)
+	server["zone"] = map[string]interface{}{
+		"id": d.Get("cloud_id").(int),
+	}
+	server["sshPassword"] = d.Get("ssh_password").(string)
+	if tags := instanceTags(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+		server["tags"] = tags
+	}
+
+	var result struct {
+		Success bool              `json:"success"`
+		Message string            `json:"msg"`
+		Errors  map[string]string `json:"errors"`
+		Server  serverDetails     `json:"server"`
+	}
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   serversPath,
+		Body: map[string]interface{}{
+			"server": server,
+		},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	if !result.Success {
+		return diag.Errorf("error registering server: %s", result.Message)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Server.ID))
+
+	if d.Get("install_agent").(bool) {
+		if err := installServerAgent(client, result.Server.ID); err != nil {
+			return diag.Errorf("error installing the agent on server %d: %s", result.Server.ID, err)
+		}
+	}
+
+	resourceServerRead(ctx, d, meta)
+	return diags
+}
+
+func resourceServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", serversPath, id),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var result struct {
+		Server serverDetails `json:"server"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	server := result.Server
+
+	d.SetId(int64ToString(server.ID))
+	d.Set("name", server.Name)
+	d.Set("description", server.Description)
+	d.Set("cloud_id", server.Zone.ID)
+	d.Set("ip_address", server.SSHHost)
+	if server.SSHPort != 0 {
+		d.Set("ssh_port", server.SSHPort)
+	}
+	d.Set("ssh_username", server.SSHUsername)
+	d.Set("key_pair_id", server.SSHKeyPair.ID)
+	d.Set("plan_id", server.Plan.ID)
+	d.Set("labels", server.Labels)
+	tags := make(map[string]interface{})
+	for _, tag := range server.Tags {
+		tags[tag["name"]] = tag["value"]
+	}
+	d.Set("tags", tags)
+	d.Set("agent_installed", server.AgentInstalled)
+	d.Set("status", server.Status)
+	d.Set("power_state", server.PowerState)
+	return diags
+}
+
+func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := toInt64(d.Id())
+
+	server := serverPayload(d)
+	// The password is not returned by the API, only send it when it changes
+	if d.HasChange("ssh_password") {
+		server["sshPassword"] = d.Get("ssh_password").(string)
+	}
+	if d.HasChange("tags") {
+		server["tags"] = instanceTags(d.Get("tags").(map[string]interface{}))
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%d", serversPath, id),
+		Body: map[string]interface{}{
+			"server": server,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	if d.HasChange("install_agent") && d.Get("install_agent").(bool) {
+		if err := installServerAgent(client, id); err != nil {
+			// plan the install again on the next apply
+			d.Set("install_agent", false)
+			return diag.Errorf("error installing the agent on server %d: %s", id, err)
+		}
+	}
+
+	return resourceServerRead(ctx, d, meta)
+}
+
+func resourceServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// Only remove the server from Morpheus, the host itself is left running
+	queryParams := map[string]string{
+		"removeResources": "off",
+	}
+	if USE_FORCE {
+		queryParams["force"] = "true"
+	}
+	resp, err := client.Execute(&morpheus.Request{
+		Method:      "DELETE",
+		Path:        fmt.Sprintf("%s/%s", serversPath, d.Id()),
+		QueryParams: queryParams,
+		Result:      &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// serverPayload returns the settings of a server sent on create and update.
+func serverPayload(d *schema.ResourceData) map[string]interface{} {
+	server := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"sshHost":     d.Get("ip_address").(string),
+		"sshPort":     d.Get("ssh_port").(int),
+		"sshUsername": d.Get("ssh_username").(string),
+		"labels":      d.Get("labels").(*schema.Set).List(),
+	}
+	if keyPairID := d.Get("key_pair_id").(int); keyPairID != 0 {
+		server["sshKeyPair"] = map[string]interface{}{
+			"id": keyPairID,
+		}
+	}
+	if planID := d.Get("plan_id").(int); planID != 0 {
+		server["plan"] = map[string]interface{}{
+			"id": planID,
+		}
+	}
+	return server
+}
+
+// installServerAgent installs the Morpheus agent on a server.
+func installServerAgent(client *morpheus.Client, id int64) error {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%d/install-agent", serversPath, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	return nil
+}
@@ -0,0 +1,108 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockServers makes the mock server install the agent on servers.
+func testMockServers(s *mockServer) {
+	s.addAction("/api/servers", "install-agent", func(record map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+		record["agentInstalled"] = true
+		return 200, map[string]interface{}{"success": true}
+	})
+}
+
+func testServerConfig(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         "tfhost",
+		"description":  description,
+		"cloud_id":     1,
+		"ip_address":   "10.0.0.25",
+		"ssh_username": "root",
+		"ssh_password": "secret",
+		"plan_id":      4,
+		"labels":       []interface{}{"brownfield"},
+		"tags":         map[string]interface{}{"owner": "ops"},
+	}
+}
+
+func TestResourceMorpheusServer_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testMockServers(s)
+	testResourceLifecycle(t, s, "morpheus_server", "/api/servers", testServerConfig("created by terraform"), testServerConfig("updated by terraform"))
+}
+
+func TestResourceMorpheusServer_payload(t *testing.T) {
+	s := newMockServer(t)
+	testMockServers(s)
+	meta := testProviderMeta(t, s)
+
+	state := testResourceApply(t, meta, "morpheus_server", nil, testServerConfig("created by terraform"))
+	server := s.requestsFor("POST", "/api/servers")[0].Body["server"].(map[string]interface{})
+	if server["sshHost"] != "10.0.0.25" || server["sshPort"] != float64(22) || server["sshUsername"] != "root" || server["sshPassword"] != "secret" {
+		t.Fatalf("unexpected connection settings: %v", server)
+	}
+	if server["zone"].(map[string]interface{})["id"] != float64(1) || server["plan"].(map[string]interface{})["id"] != float64(4) {
+		t.Fatalf("unexpected cloud or plan: %v", server)
+	}
+	tags := server["tags"].([]interface{})
+	if len(tags) != 1 || tags[0].(map[string]interface{})["name"] != "owner" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+	if len(s.requestsFor("PUT", "/api/servers/"+state.ID+"/install-agent")) != 0 {
+		t.Fatal("expected the agent not to be installed")
+	}
+
+	// the password is only sent again when it changes
+	testResourceApply(t, meta, "morpheus_server", state, testServerConfig("updated by terraform"))
+	server = s.requestsFor("PUT", "/api/servers/"+state.ID)[0].Body["server"].(map[string]interface{})
+	if _, ok := server["sshPassword"]; ok {
+		t.Fatal("expected unchanged password not to be sent")
+	}
+
+	testResourceDestroy(t, meta, "morpheus_server", state)
+	if query := s.requestsFor("DELETE", "/api/servers/"+state.ID)[0].Query; !strings.Contains(query, "removeResources=off") {
+		t.Fatalf("expected the host to be kept, got %q", query)
+	}
+}
+
+func TestResourceMorpheusServer_installAgent(t *testing.T) {
+	s := newMockServer(t)
+	testMockServers(s)
+	meta := testProviderMeta(t, s)
+	config := testServerConfig("created by terraform")
+
+	state := testResourceApply(t, meta, "morpheus_server", nil, config)
+	config["install_agent"] = true
+
+	// a failed install is planned again
+	s.failNext("PUT", "/api/servers/"+state.ID+"/install-agent", http.StatusBadRequest, 1)
+	r := testResource(t, "morpheus_server")
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	failed, diags := r.Apply(context.Background(), state, diff, meta)
+	if !diags.HasError() || failed.Attributes["install_agent"] != "false" {
+		t.Fatalf("expected the failed install to keep install_agent false, got %v %v", diags, failed.Attributes["install_agent"])
+	}
+
+	state = testResourceApply(t, meta, "morpheus_server", failed, config)
+	if len(s.requestsFor("PUT", "/api/servers/"+state.ID+"/install-agent")) != 2 {
+		t.Fatal("expected the agent to be installed")
+	}
+	if state.Attributes["agent_installed"] != "true" {
+		t.Fatalf("expected agent_installed to be true, got %q", state.Attributes["agent_installed"])
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_server", state, config)
+}
+
+func TestResourceMorpheusServer_disappears(t *testing.T) {
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_server", "/api/servers", testServerConfig("created by terraform"))
+}
@@ -0,0 +1,36 @@
+package morpheus
+
+import "testing"
+
+func TestResourceShellScriptTask_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_shell_script_task", "/api/tasks",
+		map[string]interface{}{
+			"name":           "tf_example_shell_script",
+			"code":           "tf_example_shell_script",
+			"source_type":    "local",
+			"script_content": "echo hello",
+			"sudo":           true,
+			"retryable":      true,
+			"retry_count":    1,
+		},
+		map[string]interface{}{
+			"name":           "tf_example_shell_script",
+			"code":           "tf_example_shell_script",
+			"source_type":    "local",
+			"script_content": "echo goodbye",
+			"sudo":           false,
+			"retryable":      true,
+			"retry_count":    2,
+		},
+	)
+}
+
+func TestResourceShellScriptTask_disappears(t *testing.T) {
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_shell_script_task", "/api/tasks", map[string]interface{}{
+		"name":           "tf_example_shell_script",
+		"source_type":    "local",
+		"script_content": "echo hello",
+	})
+}
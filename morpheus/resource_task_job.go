@@ -100,7 +100,7 @@ func resourceTaskJob() *schema.Resource {
 }
 
 func resourceTaskJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -177,7 +177,7 @@ func resourceTaskJobCreate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceTaskJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -198,7 +198,9 @@ func resourceTaskJobRead(ctx context.Context, d *schema.ResourceData, meta inter
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -252,7 +254,7 @@ func resourceTaskJobRead(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceTaskJobUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	job := make(map[string]interface{})
@@ -327,7 +329,7 @@ func resourceTaskJobUpdate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceTaskJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -338,7 +340,8 @@ func resourceTaskJobDelete(ctx context.Context, d *schema.ResourceData, meta int
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
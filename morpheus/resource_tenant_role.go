@@ -0,0 +1,38 @@
+package morpheus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceTenantRole manages the roles assigned to tenants. They share the
+// role endpoints and permission matrices with user roles, see
+// resource_user_role.go.
+func resourceTenantRole() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus tenant role resource.",
+		CreateContext: resourceTenantRoleCreate,
+		ReadContext:   resourceTenantRoleRead,
+		UpdateContext: resourceTenantRoleUpdate,
+		DeleteContext: resourceRoleDelete,
+		CustomizeDiff: customizeRoleDiff,
+		Schema:        roleSchema("account"),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceTenantRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createRole(ctx, d, meta, "account")
+}
+
+func resourceTenantRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readRole(ctx, d, meta, "account")
+}
+
+func resourceTenantRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateRole(ctx, d, meta, "account")
+}
@@ -0,0 +1,28 @@
+package morpheus
+
+import "testing"
+
+func TestResourceMorpheusTenantRole_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testMockRoles(s)
+	create := map[string]interface{}{
+		"name":        "tftenantrole",
+		"description": "created by terraform",
+		"feature_permission": []interface{}{
+			map[string]interface{}{"code": "provisioning", "access": "full"},
+		},
+		"global_cloud_access": "custom",
+		"cloud_access": []interface{}{
+			map[string]interface{}{"cloud_id": 1, "access": "full"},
+		},
+		"global_catalog_item_type_access": "full",
+	}
+	update := make(map[string]interface{})
+	for k, v := range create {
+		update[k] = v
+	}
+	update["description"] = "updated by terraform"
+	update["global_cloud_access"] = "full"
+	delete(update, "cloud_access")
+	testResourceLifecycle(t, s, "morpheus_tenant_role", "/api/roles", create, update)
+}
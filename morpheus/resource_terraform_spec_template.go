@@ -70,7 +70,7 @@ func resourceTerraformSpecTemplate() *schema.Resource {
 }
 
 func resourceTerraformSpecTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -119,7 +119,7 @@ func resourceTerraformSpecTemplateCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceTerraformSpecTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -142,7 +142,9 @@ func resourceTerraformSpecTemplateRead(ctx context.Context, d *schema.ResourceDa
 		// 404 is ok?
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -174,7 +176,7 @@ func resourceTerraformSpecTemplateRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceTerraformSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -218,7 +220,7 @@ func resourceTerraformSpecTemplateUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceTerraformSpecTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -229,7 +231,8 @@ func resourceTerraformSpecTemplateDelete(ctx context.Context, d *schema.Resource
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return nil
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
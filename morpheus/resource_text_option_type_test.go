@@ -0,0 +1,23 @@
+package morpheus
+
+import "testing"
+
+func TestResourceTextOptionType_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testResourceLifecycle(t, s, "morpheus_text_option_type", "/api/library/option-types",
+		map[string]interface{}{
+			"name":        "tf_example_text_option_type",
+			"field_name":  "textOptionType",
+			"field_label": "Text Option Type",
+			"help_block":  "Enter a value",
+			"required":    true,
+		},
+		map[string]interface{}{
+			"name":        "tf_example_text_option_type",
+			"field_name":  "textOptionType",
+			"field_label": "Updated Text Option Type",
+			"help_block":  "Enter a value",
+			"required":    false,
+		},
+	)
+}
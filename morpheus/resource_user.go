@@ -0,0 +1,334 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus user resource.",
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"tenant_id": {
+				Description: "The ID of the tenant to create the user in, defaults to the tenant the provider is scoped to or else the tenant of the provider credentials",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"username": {
+				Description: "The username of the user",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"email": {
+				Description: "The email address of the user",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"first_name": {
+				Description: "The first name of the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"last_name": {
+				Description: "The last name of the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"password": {
+				Description: "The password of the user, the password is not returned by the API and is only sent when it changes",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"password_reset_on_login": {
+				Description: "Whether the user must change their password on the next login, only sent on create and when it changes since the API resets it once the password is changed",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"role_ids": {
+				Description: "The IDs of the roles assigned to the user",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"receive_notifications": {
+				Description: "Whether the user receives email notifications",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"linux_username": {
+				Description: "The username of the account created for the user on linux instances",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"linux_password": {
+				Description: "The password of the account created for the user on linux instances",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"linux_key_pair_id": {
+				Description: "The ID of the key pair added to the account created for the user on linux instances",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"windows_username": {
+				Description: "The username of the account created for the user on windows instances",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"windows_password": {
+				Description: "The password of the account created for the user on windows instances",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserImport,
+		},
+	}
+}
+
+// userDetails is a user as returned by the users endpoints.
+type userDetails struct {
+	ID                   int64  `json:"id"`
+	Username             string `json:"username"`
+	Email                string `json:"email"`
+	FirstName            string `json:"firstName"`
+	LastName             string `json:"lastName"`
+	ReceiveNotifications bool   `json:"receiveNotifications"`
+	Roles                []struct {
+		ID int64 `json:"id"`
+	} `json:"roles"`
+	LinuxUsername   string      `json:"linuxUsername"`
+	LinuxKeyPairID  interface{} `json:"linuxKeyPairId"`
+	WindowsUsername string      `json:"windowsUsername"`
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	user := userPayload(d)
+	user["passwordExpired"] = d.Get("password_reset_on_login").(bool)
+	for attribute, field := range userSecrets {
+		if value := d.Get(attribute).(string); value != "" {
+			user[field] = value
+		}
+	}
+
+	var result struct {
+		Success bool              `json:"success"`
+		Message string            `json:"msg"`
+		Errors  map[string]string `json:"errors"`
+		User    userDetails       `json:"user"`
+	}
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   usersPath(d, meta),
+		Body: map[string]interface{}{
+			"user": user,
+		},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	if !result.Success {
+		return diag.Errorf("error creating user: %s", result.Message)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.User.ID))
+	resourceUserRead(ctx, d, meta)
+	return diags
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", usersPath(d, meta), id),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var result struct {
+		User userDetails `json:"user"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	user := result.User
+
+	d.SetId(int64ToString(user.ID))
+	d.Set("username", user.Username)
+	d.Set("email", user.Email)
+	d.Set("first_name", user.FirstName)
+	d.Set("last_name", user.LastName)
+	var roleIDs []int64
+	for _, role := range user.Roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	d.Set("role_ids", roleIDs)
+	d.Set("receive_notifications", user.ReceiveNotifications)
+	d.Set("linux_username", user.LinuxUsername)
+	d.Set("linux_key_pair_id", instanceResourceID(user.LinuxKeyPairID))
+	d.Set("windows_username", user.WindowsUsername)
+	return diags
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	user := userPayload(d)
+	// Passwords and the password reset are not returned by the API, only
+	// send them when they change
+	for attribute, field := range userSecrets {
+		if d.HasChange(attribute) {
+			user[field] = d.Get(attribute).(string)
+		}
+	}
+	if d.HasChange("password_reset_on_login") {
+		user["passwordExpired"] = d.Get("password_reset_on_login").(bool)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("%s/%s", usersPath(d, meta), id),
+		Body: map[string]interface{}{
+			"user": user,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceUserRead(ctx, d, meta)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", usersPath(d, meta), d.Id()),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceUserImport imports a user by its ID, or by the ID of its tenant
+// and its own ID separated by a colon for users of another tenant.
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if parts := strings.Split(d.Id(), ":"); len(parts) == 2 {
+		if parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("unexpected import ID %q, expected <user_id> or <tenant_id>:<user_id>", d.Id())
+		}
+		d.Set("tenant_id", int(toInt64(parts[0])))
+		d.SetId(parts[1])
+	}
+	d.Set("password_reset_on_login", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// userSecrets maps the write only attributes of a user to their API field.
+var userSecrets = map[string]string{
+	"password":         "password",
+	"linux_password":   "linuxPassword",
+	"windows_password": "windowsPassword",
+}
+
+// usersPath returns the endpoint of the users of the configured tenant,
+// or of the tenant the provider is scoped to.
+func usersPath(d *schema.ResourceData, meta interface{}) string {
+	if tenantID := resourceTenantID(d, meta); tenantID != 0 {
+		return fmt.Sprintf("%s/%d/users", morpheus.TenantsPath, tenantID)
+	}
+	return morpheus.UsersPath
+}
+
+// userPayload returns the settings of a user sent on create and update.
+func userPayload(d *schema.ResourceData) map[string]interface{} {
+	var roles []map[string]interface{}
+	for _, id := range d.Get("role_ids").(*schema.Set).List() {
+		roles = append(roles, map[string]interface{}{
+			"id": id.(int),
+		})
+	}
+	user := map[string]interface{}{
+		"username":             d.Get("username").(string),
+		"email":                d.Get("email").(string),
+		"firstName":            d.Get("first_name").(string),
+		"lastName":             d.Get("last_name").(string),
+		"roles":                roles,
+		"receiveNotifications": d.Get("receive_notifications").(bool),
+		"linuxUsername":        d.Get("linux_username").(string),
+		"windowsUsername":      d.Get("windows_username").(string),
+	}
+	if keyPairID := d.Get("linux_key_pair_id").(int); keyPairID != 0 {
+		user["linuxKeyPairId"] = keyPairID
+	}
+	return user
+}
@@ -0,0 +1,217 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus user group resource.",
+		CreateContext: resourceUserGroupCreate,
+		ReadContext:   resourceUserGroupRead,
+		UpdateContext: resourceUserGroupUpdate,
+		DeleteContext: resourceUserGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the user group",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the user group",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the user group",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"server_group": {
+				Description: "The name of the group the members are added to on provisioned instances",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"sudo_access": {
+				Description: "Whether the members are granted sudo access on provisioned instances",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"shared_user": {
+				Description: "Whether the members share a single account on provisioned instances instead of an account each",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"user_ids": {
+				Description: "The IDs of the users that are members of the user group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// userGroupDetails is a user group as returned by the user groups
+// endpoints. The sdk user group struct does not include the shared user
+// setting.
+type userGroupDetails struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ServerGroup string `json:"serverGroup"`
+	SudoUser    bool   `json:"sudoUser"`
+	SharedUser  bool   `json:"sharedUser"`
+	Users       []struct {
+		ID int64 `json:"id"`
+	} `json:"users"`
+}
+
+func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"userGroup": userGroupPayload(d),
+		},
+	}
+	resp, err := client.CreateUserGroup(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateUserGroupResult)
+	if !result.Success {
+		return diag.Errorf("error creating user group: %s", result.Message)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.UserGroup.ID))
+	resourceUserGroupRead(ctx, d, meta)
+	return diags
+}
+
+func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.GetUserGroup(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var result struct {
+		UserGroup userGroupDetails `json:"userGroup"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return diag.FromErr(err)
+	}
+	userGroup := result.UserGroup
+
+	d.SetId(int64ToString(userGroup.ID))
+	d.Set("name", userGroup.Name)
+	d.Set("description", userGroup.Description)
+	d.Set("server_group", userGroup.ServerGroup)
+	d.Set("sudo_access", userGroup.SudoUser)
+	d.Set("shared_user", userGroup.SharedUser)
+	var userIDs []int64
+	for _, user := range userGroup.Users {
+		userIDs = append(userIDs, user.ID)
+	}
+	d.Set("user_ids", userIDs)
+	return diags
+}
+
+func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"userGroup": userGroupPayload(d),
+		},
+	}
+	resp, err := client.UpdateUserGroup(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateUserGroupResult)
+	if !result.Success {
+		return diag.Errorf("error updating user group: %s", result.Message)
+	}
+
+	return resourceUserGroupRead(ctx, d, meta)
+}
+
+func resourceUserGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteUserGroupResult(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// userGroupPayload returns the settings of a user group sent on create and
+// update. The members are always sent so removed users leave the group.
+func userGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	users := make([]map[string]interface{}, 0)
+	for _, id := range d.Get("user_ids").(*schema.Set).List() {
+		users = append(users, map[string]interface{}{
+			"id": id.(int),
+		})
+	}
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"serverGroup": d.Get("server_group").(string),
+		"sudoUser":    d.Get("sudo_access").(bool),
+		"sharedUser":  d.Get("shared_user").(bool),
+		"users":       users,
+	}
+}
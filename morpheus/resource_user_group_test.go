@@ -0,0 +1,52 @@
+package morpheus
+
+import "testing"
+
+func testUserGroupConfig(s *mockServer) map[string]interface{} {
+	alice := s.seed("/api/users", map[string]interface{}{"username": "alice"})
+	bob := s.seed("/api/users", map[string]interface{}{"username": "bob"})
+	return map[string]interface{}{
+		"name":         "tfusergroup",
+		"description":  "created by terraform",
+		"server_group": "ops",
+		"sudo_access":  true,
+		"user_ids":     []interface{}{int(alice), int(bob)},
+	}
+}
+
+func TestResourceMorpheusUserGroup_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	create := testUserGroupConfig(s)
+	update := make(map[string]interface{})
+	for k, v := range create {
+		update[k] = v
+	}
+	update["description"] = "updated by terraform"
+	update["shared_user"] = true
+	update["user_ids"] = create["user_ids"].([]interface{})[:1]
+	testResourceLifecycle(t, s, "morpheus_user_group", "/api/user-groups", create, update)
+}
+
+func TestResourceMorpheusUserGroup_members(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testUserGroupConfig(s)
+
+	state := testResourceApply(t, meta, "morpheus_user_group", nil, config)
+	if state.Attributes["user_ids.#"] != "2" || state.Attributes["sudo_access"] != "true" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	delete(config, "user_ids")
+	state = testResourceApply(t, meta, "morpheus_user_group", state, config)
+	users := s.requestsFor("PUT", "/api/user-groups/"+state.ID)[0].Body["userGroup"].(map[string]interface{})["users"]
+	if members, ok := users.([]interface{}); !ok || len(members) != 0 {
+		t.Fatalf("expected the members to be removed, got %v", users)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_user_group", state, config)
+}
+
+func TestResourceMorpheusUserGroup_disappears(t *testing.T) {
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_user_group", "/api/user-groups", testUserGroupConfig(s))
+}
@@ -0,0 +1,417 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// rolePermission describes a permission matrix of a role, the attribute
+// managing it and the keys of the permission set returned by the API.
+// Permissions with a global access only apply their individual entries
+// when the global access is custom.
+type rolePermission struct {
+	attribute   string
+	idAttribute string
+	global      string
+	globalKey   string
+	key         string
+	description string
+	access      []string
+	tenantRole  bool
+}
+
+var rolePermissions = []rolePermission{
+	{"feature_permission", "code", "", "", "featurePermissions", "The access to a feature, features that are not listed have no access", nil, true},
+	{"group_access", "group_id", "global_group_access", "globalSiteAccess", "sites", "The access to a group, requires `global_group_access` to be custom", []string{"none", "read", "full"}, false},
+	{"cloud_access", "cloud_id", "global_cloud_access", "globalZoneAccess", "zones", "The access to a cloud, requires `global_cloud_access` to be custom", []string{"none", "read", "full"}, true},
+	{"instance_type_access", "instance_type_id", "global_instance_type_access", "globalInstanceTypeAccess", "instanceTypePermissions", "The access to an instance type, requires `global_instance_type_access` to be custom", []string{"none", "full"}, true},
+	{"blueprint_access", "blueprint_id", "global_blueprint_access", "globalAppTemplateAccess", "appTemplatePermissions", "The access to a blueprint, requires `global_blueprint_access` to be custom", []string{"none", "full"}, true},
+	{"catalog_item_type_access", "catalog_item_type_id", "global_catalog_item_type_access", "globalCatalogItemTypeAccess", "catalogItemTypePermissions", "The access to a catalog item type, requires `global_catalog_item_type_access` to be custom", []string{"none", "full"}, true},
+	{"workflow_access", "workflow_id", "global_workflow_access", "globalTaskSetAccess", "taskSetPermissions", "The access to a workflow, requires `global_workflow_access` to be custom", []string{"none", "full"}, true},
+}
+
+func resourceUserRole() *schema.Resource {
+	s := roleSchema("user")
+	s["multitenant"] = &schema.Schema{
+		Description: "Whether the role is copied to every subtenant",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	s["multitenant_locked"] = &schema.Schema{
+		Description: "Whether subtenants are prevented from changing the copies of a multitenant role",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	s["default_persona"] = &schema.Schema{
+		Description:  "The persona users of the role land on after logging in (standard, serviceCatalog, vdi)",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice([]string{"standard", "serviceCatalog", "vdi"}, false),
+	}
+
+	return &schema.Resource{
+		Description:   "Provides a Morpheus user role resource.",
+		CreateContext: resourceUserRoleCreate,
+		ReadContext:   resourceUserRoleRead,
+		UpdateContext: resourceUserRoleUpdate,
+		DeleteContext: resourceRoleDelete,
+		CustomizeDiff: customizeRoleDiff,
+		Schema:        s,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createRole(ctx, d, meta, "user")
+}
+
+func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readRole(ctx, d, meta, "user")
+}
+
+func resourceUserRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateRole(ctx, d, meta, "user")
+}
+
+// roleSchema returns the attributes shared by user and tenant roles along
+// with the permission matrices of the role type.
+func roleSchema(roleType string) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Description: "The ID of the role",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the role",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"description": {
+			Description: "The description of the role",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+	for _, p := range rolePermissions {
+		if roleType == "account" && !p.tenantRole {
+			continue
+		}
+		noun := strings.ReplaceAll(strings.TrimSuffix(p.idAttribute, "_id"), "_", " ")
+		idSchema := &schema.Schema{
+			Description: fmt.Sprintf("The ID of the %s", noun),
+			Type:        schema.TypeInt,
+			Required:    true,
+		}
+		accessSchema := &schema.Schema{
+			Description: "The access level",
+			Type:        schema.TypeString,
+			Required:    true,
+		}
+		if p.idAttribute == "code" {
+			idSchema.Description = "The code of the feature"
+			idSchema.Type = schema.TypeString
+		}
+		if p.access != nil {
+			accessSchema.Description = fmt.Sprintf("The access level (%s)", strings.Join(p.access, ", "))
+			accessSchema.ValidateFunc = validation.StringInSlice(p.access, false)
+		}
+		s[p.attribute] = &schema.Schema{
+			Description: p.description,
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					p.idAttribute: idSchema,
+					"access":      accessSchema,
+				},
+			},
+		}
+		if p.global != "" {
+			values := append([]string{}, p.access...)
+			values = append(values, "custom")
+			s[p.global] = &schema.Schema{
+				Description:  fmt.Sprintf("The access to every %s (%s), custom applies the access of each %s set in `%s`", noun, strings.Join(values, ", "), noun, p.attribute),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice(values, false),
+			}
+		}
+	}
+	return s
+}
+
+// customizeRoleDiff refuses individual access entries next to a global
+// access other than custom. The API ignores them, they would be planned
+// again on every run.
+func customizeRoleDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, p := range rolePermissions {
+		if p.global == "" {
+			continue
+		}
+		entries, ok := d.Get(p.attribute).(*schema.Set)
+		if !ok || entries.Len() == 0 {
+			continue
+		}
+		// the global access may not be known until apply
+		if !d.NewValueKnown(p.global) {
+			continue
+		}
+		if global := d.Get(p.global).(string); global != "custom" {
+			return fmt.Errorf("%s is only used when %s is custom, got %q", p.attribute, p.global, global)
+		}
+	}
+	return nil
+}
+
+func createRole(ctx context.Context, d *schema.ResourceData, meta interface{}, roleType string) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	role := rolePayload(d, roleType)
+	role["roleType"] = roleType
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"role":          role,
+			"permissionSet": rolePermissionSet(d, roleType),
+		},
+	}
+	resp, err := client.CreateRole(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateRoleResult)
+	if !result.Success {
+		return diag.Errorf("error creating role: %s", result.Message)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Role.ID))
+	readRole(ctx, d, meta, roleType)
+	return diags
+}
+
+func readRole(ctx context.Context, d *schema.ResourceData, meta interface{}, roleType string) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	resp, err := client.GetRole(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetRoleResult)
+	role := result.Role
+	if role.RoleType != "" && role.RoleType != roleType {
+		return diag.Errorf("role %s has the role type %s, expected %s", id, role.RoleType, roleType)
+	}
+	d.SetId(int64ToString(role.ID))
+	d.Set("name", role.Authority)
+	d.Set("description", role.Description)
+	if roleType == "user" {
+		d.Set("multitenant", role.MultiTenant)
+		d.Set("multitenant_locked", role.MultiTenantLocked)
+		d.Set("default_persona", role.DefaultPersona.Code)
+	}
+
+	// The typed result has a struct per permission, read them generically
+	var permissionSet map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &permissionSet); err != nil {
+		return diag.FromErr(err)
+	}
+	for _, p := range rolePermissions {
+		if roleType == "account" && !p.tenantRole {
+			continue
+		}
+		if p.global != "" {
+			global, _ := permissionSet[p.globalKey].(string)
+			if global == "" {
+				global = "none"
+			}
+			d.Set(p.global, global)
+		}
+		d.Set(p.attribute, flattenRolePermissions(p, permissionSet, d.Get(p.attribute).(*schema.Set).List()))
+	}
+	return diags
+}
+
+func updateRole(ctx context.Context, d *schema.ResourceData, meta interface{}, roleType string) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"role":          rolePayload(d, roleType),
+			"permissionSet": rolePermissionSet(d, roleType),
+		},
+	}
+	resp, err := client.UpdateRole(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateRoleResult)
+	if !result.Success {
+		return diag.Errorf("error updating role: %s", result.Message)
+	}
+
+	return readRole(ctx, d, meta, roleType)
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteRole(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// rolePayload returns the settings of a role sent on create and update.
+func rolePayload(d *schema.ResourceData, roleType string) map[string]interface{} {
+	role := map[string]interface{}{
+		"authority":   d.Get("name").(string),
+		"description": d.Get("description").(string),
+	}
+	if roleType == "user" {
+		role["multitenant"] = d.Get("multitenant").(bool)
+		role["multitenantLocked"] = d.Get("multitenant_locked").(bool)
+		if persona := d.Get("default_persona").(string); persona != "" {
+			role["defaultPersona"] = map[string]interface{}{
+				"code": persona,
+			}
+		}
+	}
+	return role
+}
+
+// rolePermissionSet returns the permission matrices of a role. Entries
+// removed from the configuration are sent with no access so the API
+// revokes them.
+func rolePermissionSet(d *schema.ResourceData, roleType string) map[string]interface{} {
+	permissionSet := make(map[string]interface{})
+	for _, p := range rolePermissions {
+		if roleType == "account" && !p.tenantRole {
+			continue
+		}
+		if p.global != "" {
+			permissionSet[p.globalKey] = d.Get(p.global).(string)
+		}
+		apiID := "id"
+		if p.idAttribute == "code" {
+			apiID = "code"
+		}
+		entries := make([]map[string]interface{}, 0)
+		configured := make(map[interface{}]bool)
+		for _, raw := range d.Get(p.attribute).(*schema.Set).List() {
+			entry := raw.(map[string]interface{})
+			configured[entry[p.idAttribute]] = true
+			entries = append(entries, map[string]interface{}{
+				apiID:    entry[p.idAttribute],
+				"access": entry["access"],
+			})
+		}
+		old, _ := d.GetChange(p.attribute)
+		if old != nil {
+			for _, raw := range old.(*schema.Set).List() {
+				entry := raw.(map[string]interface{})
+				if !configured[entry[p.idAttribute]] {
+					configured[entry[p.idAttribute]] = true
+					entries = append(entries, map[string]interface{}{
+						apiID:    entry[p.idAttribute],
+						"access": "none",
+					})
+				}
+			}
+		}
+		permissionSet[p.key] = entries
+	}
+	return permissionSet
+}
+
+// flattenRolePermissions maps the entries of a permission matrix returned
+// by the API to its attribute. Entries without access are left out unless
+// they are set to none in the state, as are the entries of a matrix whose
+// global access is not custom.
+func flattenRolePermissions(p rolePermission, permissionSet map[string]interface{}, current []interface{}) []map[string]interface{} {
+	permissions := make([]map[string]interface{}, 0)
+	if p.global != "" && permissionSet[p.globalKey] != "custom" {
+		return permissions
+	}
+	granted := make(map[interface{}]bool)
+	entries, _ := permissionSet[p.key].([]interface{})
+	for _, raw := range entries {
+		entry, _ := raw.(map[string]interface{})
+		access, _ := entry["access"].(string)
+		if access == "" || access == "none" {
+			continue
+		}
+		row := map[string]interface{}{
+			"access": access,
+		}
+		if p.idAttribute == "code" {
+			row["code"] = entry["code"]
+		} else {
+			row[p.idAttribute] = instanceResourceID(entry["id"])
+		}
+		granted[row[p.idAttribute]] = true
+		permissions = append(permissions, row)
+	}
+	for _, raw := range current {
+		entry := raw.(map[string]interface{})
+		if entry["access"] == "none" && !granted[entry[p.idAttribute]] {
+			permissions = append(permissions, map[string]interface{}{
+				p.idAttribute: entry[p.idAttribute],
+				"access":      "none",
+			})
+		}
+	}
+	return permissions
+}
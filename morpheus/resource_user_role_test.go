@@ -0,0 +1,218 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockRoles stores the permission set sent with roles and returns it
+// next to the role, like the API does. Updated permissions are merged into
+// the existing ones by id or code.
+func testMockRoles(s *mockServer) {
+	s.addCreateHook("/api/roles", func(record map[string]interface{}, body map[string]interface{}) {
+		record["permissionSet"] = map[string]interface{}{}
+		mockRolePermissions(record, body)
+	})
+	s.addUpdateHook("/api/roles", mockRolePermissions)
+	s.setMemberPayload("/api/roles", func(record map[string]interface{}) map[string]interface{} {
+		role := copyMockRecord(record)
+		delete(role, "permissionSet")
+		payload := map[string]interface{}{"role": role}
+		permissionSet, _ := record["permissionSet"].(map[string]interface{})
+		for k, v := range permissionSet {
+			payload[k] = v
+		}
+		return payload
+	})
+}
+
+func mockRolePermissions(record map[string]interface{}, body map[string]interface{}) {
+	stored, _ := record["permissionSet"].(map[string]interface{})
+	permissionSet, _ := body["permissionSet"].(map[string]interface{})
+	for k, v := range permissionSet {
+		entries, ok := v.([]interface{})
+		if !ok {
+			stored[k] = v
+			continue
+		}
+		existing, _ := stored[k].([]interface{})
+		for _, raw := range entries {
+			entry := raw.(map[string]interface{})
+			replaced := false
+			for i, current := range existing {
+				if mockRolePermissionKey(current.(map[string]interface{})) == mockRolePermissionKey(entry) {
+					existing[i] = entry
+					replaced = true
+				}
+			}
+			if !replaced {
+				existing = append(existing, entry)
+			}
+		}
+		stored[k] = existing
+	}
+}
+
+func mockRolePermissionKey(entry map[string]interface{}) string {
+	if code, ok := entry["code"]; ok {
+		return fmt.Sprint(code)
+	}
+	return fmt.Sprint(entry["id"])
+}
+
+// mockRolePermission returns the access of the entry with the given id or
+// code in the permission matrix key of a stored role.
+func mockRolePermission(record map[string]interface{}, key string, id string) interface{} {
+	permissionSet, _ := record["permissionSet"].(map[string]interface{})
+	entries, _ := permissionSet[key].([]interface{})
+	for _, raw := range entries {
+		entry := raw.(map[string]interface{})
+		if mockRolePermissionKey(entry) == id {
+			return entry["access"]
+		}
+	}
+	return nil
+}
+
+func testUserRoleConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":            "tfrole",
+		"description":     "created by terraform",
+		"multitenant":     true,
+		"default_persona": "standard",
+		"feature_permission": []interface{}{
+			map[string]interface{}{"code": "provisioning", "access": "full"},
+			map[string]interface{}{"code": "admin-users", "access": "read"},
+		},
+		"global_group_access": "custom",
+		"group_access": []interface{}{
+			map[string]interface{}{"group_id": 1, "access": "full"},
+			map[string]interface{}{"group_id": 2, "access": "read"},
+		},
+		"global_cloud_access":         "full",
+		"global_instance_type_access": "custom",
+		"instance_type_access": []interface{}{
+			map[string]interface{}{"instance_type_id": 3, "access": "full"},
+		},
+		"global_blueprint_access":         "full",
+		"global_catalog_item_type_access": "none",
+		"global_workflow_access":          "custom",
+		"workflow_access": []interface{}{
+			map[string]interface{}{"workflow_id": 4, "access": "full"},
+		},
+	}
+}
+
+func TestResourceMorpheusUserRole_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	testMockRoles(s)
+	create := testUserRoleConfig()
+	update := testUserRoleConfig()
+	update["description"] = "updated by terraform"
+	update["group_access"] = []interface{}{
+		map[string]interface{}{"group_id": 1, "access": "read"},
+	}
+	testResourceLifecycle(t, s, "morpheus_user_role", "/api/roles", create, update)
+}
+
+func TestResourceMorpheusUserRole_permissions(t *testing.T) {
+	s := newMockServer(t)
+	testMockRoles(s)
+	meta := testProviderMeta(t, s)
+	config := testUserRoleConfig()
+
+	state := testResourceApply(t, meta, "morpheus_user_role", nil, config)
+	record := s.record("/api/roles", toInt64(state.ID))
+	if record["roleType"] != "user" || record["authority"] != "tfrole" {
+		t.Fatalf("unexpected role: %v", record)
+	}
+	if state.Attributes["feature_permission.#"] != "2" || state.Attributes["group_access.#"] != "2" || state.Attributes["cloud_access.#"] != "0" {
+		t.Fatalf("unexpected permissions in state: %v", state.Attributes)
+	}
+
+	// permissions removed from the configuration are revoked
+	config["feature_permission"] = []interface{}{
+		map[string]interface{}{"code": "provisioning", "access": "full"},
+	}
+	state = testResourceApply(t, meta, "morpheus_user_role", state, config)
+	if access := mockRolePermission(s.record("/api/roles", toInt64(state.ID)), "featurePermissions", "admin-users"); access != "none" {
+		t.Fatalf("expected admin-users to be revoked, got %v", access)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_user_role", state, config)
+
+	// permissions explicitly set to none are kept
+	config["feature_permission"] = []interface{}{
+		map[string]interface{}{"code": "provisioning", "access": "full"},
+		map[string]interface{}{"code": "admin-users", "access": "none"},
+	}
+	config["group_access"] = []interface{}{
+		map[string]interface{}{"group_id": 1, "access": "full"},
+		map[string]interface{}{"group_id": 2, "access": "none"},
+	}
+	state = testResourceApply(t, meta, "morpheus_user_role", state, config)
+	if access := mockRolePermission(s.record("/api/roles", toInt64(state.ID)), "sites", "2"); access != "none" {
+		t.Fatalf("expected group 2 to be revoked, got %v", access)
+	}
+	if state.Attributes["feature_permission.#"] != "2" || state.Attributes["group_access.#"] != "2" {
+		t.Fatalf("expected the permissions set to none to be kept, got %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_user_role", state, config)
+	config["feature_permission"] = []interface{}{
+		map[string]interface{}{"code": "provisioning", "access": "full"},
+	}
+	config["group_access"] = testUserRoleConfig()["group_access"]
+	state = testResourceApply(t, meta, "morpheus_user_role", state, config)
+
+	// permissions granted outside of terraform show up as drift
+	record = s.record("/api/roles", toInt64(state.ID))
+	permissionSet := record["permissionSet"].(map[string]interface{})
+	permissionSet["featurePermissions"] = append(permissionSet["featurePermissions"].([]interface{}), map[string]interface{}{"code": "admin-roles", "access": "full"})
+	state = testResourceRefresh(t, meta, "morpheus_user_role", state)
+	if state.Attributes["feature_permission.#"] != "2" {
+		t.Fatalf("expected the granted permission to be read, got %v", state.Attributes)
+	}
+}
+
+func TestResourceMorpheusUserRole_accessWithoutCustom(t *testing.T) {
+	s := newMockServer(t)
+	testMockRoles(s)
+	meta := testProviderMeta(t, s)
+	config := testUserRoleConfig()
+	config["cloud_access"] = []interface{}{
+		map[string]interface{}{"cloud_id": 5, "access": "read"},
+	}
+
+	// the entries would be ignored by the API and planned on every run
+	err := testResourcePlanError(t, meta, "morpheus_user_role", nil, config)
+	if !strings.Contains(err, `cloud_access is only used when global_cloud_access is custom, got "full"`) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tenantRole := map[string]interface{}{
+		"name":         "tftenantrole",
+		"cloud_access": config["cloud_access"],
+	}
+	if err := testResourcePlanError(t, meta, "morpheus_tenant_role", nil, tenantRole); !strings.Contains(err, `got "none"`) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	config["global_cloud_access"] = "custom"
+	state := testResourceApply(t, meta, "morpheus_user_role", nil, config)
+	testResourcePlanEmpty(t, meta, "morpheus_user_role", state, config)
+}
+
+func TestResourceMorpheusUserRole_wrongType(t *testing.T) {
+	s := newMockServer(t)
+	testMockRoles(s)
+	meta := testProviderMeta(t, s)
+	id := s.seed("/api/roles", map[string]interface{}{"authority": "tftenantrole", "roleType": "account", "permissionSet": map[string]interface{}{}})
+
+	r := testResource(t, "morpheus_user_role")
+	diags := r.ReadContext(context.Background(), r.Data(&terraform.InstanceState{ID: int64ToString(id)}), meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "has the role type account, expected user") {
+		t.Fatalf("expected the tenant role to be refused, got %v", diags)
+	}
+}
@@ -0,0 +1,96 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testUserConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"username":       "svc-deploy",
+		"email":          "svc-deploy@example.com",
+		"first_name":     "Deploy",
+		"last_name":      "Service",
+		"password":       "Passw0rd!",
+		"role_ids":       []interface{}{3, 4},
+		"linux_username": "svcdeploy",
+		"linux_password": "L1nuxPassw0rd!",
+	}
+}
+
+func TestResourceMorpheusUser_lifecycle(t *testing.T) {
+	s := newMockServer(t)
+	create := testUserConfig()
+	update := testUserConfig()
+	update["email"] = "deploy@example.com"
+	update["role_ids"] = []interface{}{3}
+	update["receive_notifications"] = false
+	testResourceLifecycle(t, s, "morpheus_user", "/api/users", create, update)
+}
+
+func TestResourceMorpheusUser_password(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testUserConfig()
+
+	state := testResourceApply(t, meta, "morpheus_user", nil, config)
+	create := s.requestsFor("POST", "/api/users")[0].Body["user"].(map[string]interface{})
+	if create["password"] != "Passw0rd!" || create["linuxPassword"] != "L1nuxPassw0rd!" || create["passwordExpired"] != false {
+		t.Fatalf("expected the passwords to be sent on create, got %v", create)
+	}
+
+	config["first_name"] = "Deployment"
+	state = testResourceApply(t, meta, "morpheus_user", state, config)
+	update := s.requestsFor("PUT", "/api/users/"+state.ID)[0].Body["user"].(map[string]interface{})
+	if _, ok := update["password"]; ok {
+		t.Fatalf("expected the unchanged password not to be sent, got %v", update)
+	}
+
+	config["password"] = "N3wPassw0rd!"
+	config["password_reset_on_login"] = true
+	state = testResourceApply(t, meta, "morpheus_user", state, config)
+	update = s.requestsFor("PUT", "/api/users/"+state.ID)[1].Body["user"].(map[string]interface{})
+	if update["password"] != "N3wPassw0rd!" || update["passwordExpired"] != true {
+		t.Fatalf("expected the new password to be sent, got %v", update)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_user", state, config)
+
+	// the API clears the reset once the user changed their password
+	s.record("/api/users", toInt64(state.ID))["passwordExpired"] = false
+	state = testResourceRefresh(t, meta, "morpheus_user", state)
+	testResourcePlanEmpty(t, meta, "morpheus_user", state, config)
+	config["last_name"] = "Bot"
+	state = testResourceApply(t, meta, "morpheus_user", state, config)
+	update = s.requestsFor("PUT", "/api/users/"+state.ID)[2].Body["user"].(map[string]interface{})
+	if _, ok := update["passwordExpired"]; ok {
+		t.Fatalf("expected the unchanged password reset not to be sent, got %v", update)
+	}
+}
+
+func TestResourceMorpheusUser_tenant(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	tenant := s.seed("/api/accounts", map[string]interface{}{"name": "tftenant"})
+	path := fmt.Sprintf("/api/accounts/%d/users", tenant)
+	s.addCollection(path, "user", "users", nil)
+	config := testUserConfig()
+	config["tenant_id"] = int(tenant)
+
+	state := testResourceApply(t, meta, "morpheus_user", nil, config)
+	if s.record(path, toInt64(state.ID)) == nil {
+		t.Fatalf("user %s was not created in tenant %d", state.ID, tenant)
+	}
+
+	imported := testResourceImport(t, meta, "morpheus_user", fmt.Sprintf("%d:%s", tenant, state.ID))
+	if imported.ID != state.ID || imported.Attributes["tenant_id"] != int64ToString(tenant) {
+		t.Fatalf("unexpected imported state: %v", imported.Attributes)
+	}
+	delete(config, "password")
+	delete(config, "linux_password")
+	testResourcePlanEmpty(t, meta, "morpheus_user", imported, config)
+}
+
+func TestResourceMorpheusUser_disappears(t *testing.T) {
+	s := newMockServer(t)
+	testResourceDisappears(t, s, "morpheus_user", "/api/users", testUserConfig())
+}
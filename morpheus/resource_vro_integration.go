@@ -87,7 +87,7 @@ func resourceVrealizeOrchestratorIntegration() *schema.Resource {
 }
 
 func resourceVrealizeOrchestratorIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -127,7 +127,7 @@ func resourceVrealizeOrchestratorIntegrationCreate(ctx context.Context, d *schem
 }
 
 func resourceVrealizeOrchestratorIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -148,7 +148,9 @@ func resourceVrealizeOrchestratorIntegrationRead(ctx context.Context, d *schema.
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -171,7 +173,7 @@ func resourceVrealizeOrchestratorIntegrationRead(ctx context.Context, d *schema.
 }
 
 func resourceVrealizeOrchestratorIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -207,7 +209,7 @@ func resourceVrealizeOrchestratorIntegrationUpdate(ctx context.Context, d *schem
 }
 
 func resourceVrealizeOrchestratorIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -218,7 +220,8 @@ func resourceVrealizeOrchestratorIntegrationDelete(ctx context.Context, d *schem
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -201,16 +201,17 @@ func resourceVsphereCloud() *schema.Resource {
 				Default:      "private",
 			},
 			"tenant_id": {
-				Description: "The id of the morpheus tenant the cloud is assigned to",
+				Description: "The id of the morpheus tenant the cloud is assigned to, defaults to the tenant the provider is scoped to",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 			},
 		},
 	}
 }
 
 func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -291,6 +292,12 @@ func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, met
 	keyboard_layout := d.Get("keyboard_layout")
 	guidance := d.Get("guidance")
 
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
+
 	payload := map[string]interface{}{
 		"zone": map[string]interface{}{
 			"name":                  name,
@@ -302,9 +309,9 @@ func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, met
 			"costingMode":           costing,
 			"consoleKeymap":         keyboard_layout,
 			"description":           d.Get("description").(string),
-			"accountId":             d.Get("tenant_id").(string),
+			"accountId":             tenantID,
 			"account": map[string]interface{}{
-				"id": d.Get("tenant_id").(string),
+				"id": tenantID,
 			},
 			"guidanceMode": guidance,
 			"timezone":     time_zone,
@@ -333,7 +340,7 @@ func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceVsphereCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -354,6 +361,9 @@ func resourceVsphereCloudRead(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -437,7 +447,7 @@ func resourceVsphereCloudRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	code := d.Get("code").(string)
@@ -510,6 +520,12 @@ func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, met
 	guidance := d.Get("guidance")
 	time_zone := d.Get("time_zone")
 
+	// default to the tenant the provider is scoped to
+	tenantID := ""
+	if id := resourceTenantID(d, meta); id != 0 {
+		tenantID = int64ToString(id)
+	}
+
 	payload := map[string]interface{}{
 		"zone": map[string]interface{}{
 			"name":                  name,
@@ -519,9 +535,9 @@ func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, met
 			"agentMode":             agent_install_mode,
 			"autoRecoverPowerState": automatically_power_on_vms,
 			"description":           d.Get("description").(string),
-			"accountId":             d.Get("tenant_id").(string),
+			"accountId":             tenantID,
 			"account": map[string]interface{}{
-				"id": d.Get("tenant_id").(string),
+				"id": tenantID,
 			},
 			"costingMode":   costing,
 			"consoleKeymap": keyboard_layout,
@@ -550,7 +566,7 @@ func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceVsphereCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -561,7 +577,8 @@ func resourceVsphereCloudDelete(ctx context.Context, d *schema.ResourceData, met
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -2,14 +2,15 @@ package morpheus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceVsphereInstance() *schema.Resource {
@@ -20,11 +21,12 @@ func resourceVsphereInstance() *schema.Resource {
 		UpdateContext: resourceVsphereInstanceUpdate,
 		DeleteContext: resourceVsphereInstanceDelete,
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(45 * time.Minute),
+			Create: schema.DefaultTimeout(3 * time.Hour),
 			Read:   schema.DefaultTimeout(5 * time.Minute),
 			Update: schema.DefaultTimeout(45 * time.Minute),
 			Delete: schema.DefaultTimeout(45 * time.Minute),
 		},
+		CustomizeDiff: customizeInstanceResizeDiff,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of the instance",
@@ -67,11 +69,39 @@ func resourceVsphereInstance() *schema.Resource {
 				Required:    true,
 			},
 			"plan_id": {
-				Description: "The service plan associated with the instance",
+				Description: "The service plan associated with the instance, changing the plan resizes the instance in place",
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 			},
+			"cores": {
+				Description: "The number of processor cores of the instance, only supported when the service plan allows custom cores",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"memory": {
+				Description: "The amount of memory of the instance in bytes, only supported when the service plan allows custom memory",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"power_state": {
+				Description:  "The power state of the instance (running, stopped, suspended)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "stopped", "suspended"}, false),
+			},
+			"allow_reboot_on_resize": {
+				Description: "Whether the instance may be rebooted to apply a change of the plan, cores, memory, volumes or network interfaces, planning these changes fails otherwise",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"delete_on_failure": {
+				Description: "Whether to delete the instance when provisioning fails, the failed instance is otherwise kept and replaced on the next apply",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"resource_pool_id": {
 				Description: "The ID of the resource pool to provision the instance to",
 				Type:        schema.TypeInt,
@@ -107,18 +137,20 @@ func resourceVsphereInstance() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"workflow_id": {
-				Description:   "The ID of the provisioning workflow to execute (`workflow_name` can be used alternatively, only one is needed)",
-				Type:          schema.TypeInt,
-				ForceNew:      true,
-				Optional:      true,
-				ConflictsWith: []string{"workflow_name"},
+				Description:      "The ID of the provisioning workflow to execute (`workflow_name` can be used alternatively, only one is needed)",
+				Type:             schema.TypeInt,
+				ForceNew:         true,
+				Optional:         true,
+				ConflictsWith:    []string{"workflow_name"},
+				DiffSuppressFunc: suppressProvisioningWorkflowDiff,
 			},
 			"workflow_name": {
-				Description:   "The name of the provisioning workflow to execute (`workflow_id` can be used alternatively, only one is needed)",
-				Type:          schema.TypeString,
-				ForceNew:      true,
-				Optional:      true,
-				ConflictsWith: []string{"workflow_id"},
+				Description:      "The name of the provisioning workflow to execute (`workflow_id` can be used alternatively, only one is needed)",
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ConflictsWith:    []string{"workflow_id"},
+				DiffSuppressFunc: suppressProvisioningWorkflowDiff,
 			},
 			"create_user": {
 				Description: "Whether to create a user account on the instance that is associated with the provisioning user account",
@@ -167,9 +199,10 @@ func resourceVsphereInstance() *schema.Resource {
 							Optional:    true,
 						},
 						"value": {
-							Type:        schema.TypeString,
-							Description: "The value of the environment variable",
-							Optional:    true,
+							Type:             schema.TypeString,
+							Description:      "The value of the environment variable",
+							Optional:         true,
+							DiffSuppressFunc: suppressMaskedEnvironmentVariableDiff,
 						},
 						"export": {
 							Type:        schema.TypeBool,
@@ -185,9 +218,10 @@ func resourceVsphereInstance() *schema.Resource {
 				},
 			},
 			"volumes": {
-				Description: "The instance volumes to create",
+				Description: "The instance volumes, data volumes can be added, resized and removed in place",
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"root": {
@@ -228,9 +262,10 @@ func resourceVsphereInstance() *schema.Resource {
 				},
 			},
 			"interfaces": {
-				Description: "The instance network interfaces to create",
+				Description: "The instance network interfaces, interfaces can be added and removed in place",
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"network_id": {
@@ -240,13 +275,13 @@ func resourceVsphereInstance() *schema.Resource {
 							Computed:    true,
 						},
 						"ip_address": {
-							Description: "",
+							Description: "The static IP address to assign to the network interface",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
 						},
 						"ip_mode": {
-							Description: "",
+							Description: "The IP address assignment mode of the network interface (i.e. - dhcp, static)",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
@@ -257,18 +292,60 @@ func resourceVsphereInstance() *schema.Resource {
 							Optional:    true,
 							Computed:    true,
 						},
+						"assigned_ip": {
+							Description: "The IP address assigned to the network interface",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
+			"status": {
+				Description: "The status of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"hostname": {
+				Description: "The hostname of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"primary_ip_address": {
+				Description: "The IP address used to connect to the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ip_addresses": {
+				Description: "The IP addresses assigned to the instance",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"server_ids": {
+				Description: "The IDs of the servers (virtual machines) of the instance",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"external_id": {
+				Description: "The ID of the virtual machine in the cloud",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"date_created": {
+				Description: "The date the instance was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVsphereInstanceImport,
 		},
 	}
 }
 
 func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -379,6 +456,15 @@ func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData,
 		"config":   config,
 	}
 
+	// Custom cores and memory
+	servicePlanOptions, err := instanceServicePlanOptions(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(servicePlanOptions) > 0 {
+		payload["servicePlanOptions"] = servicePlanOptions
+	}
+
 	// tags
 	if d.Get("tags") != nil {
 		tagsInput := d.Get("tags").(map[string]interface{})
@@ -432,38 +518,28 @@ func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData,
 	result := resp.Result.(*morpheus.CreateInstanceResult)
 	instance := result.Instance
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"provisioning", "starting", "stopping"},
-		Target:  []string{"running", "failed", "warning", "denied", "cancelled"},
-		Refresh: func() (interface{}, string, error) {
-			instanceDetails, err := client.GetInstance(instance.ID, &morpheus.Request{})
-			if err != nil {
-				return "", "", err
+	// Set the id before waiting so that a failed instance is tainted
+	d.SetId(int64ToString(instance.ID))
+	if err := waitForInstanceProvision(ctx, client, instance.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		if d.Get("delete_on_failure").(bool) {
+			if cleanupErr := deleteFailedInstance(client, instance.ID); cleanupErr != nil {
+				return diag.Errorf("error creating instance: %s (deleting the failed instance: %s)", err, cleanupErr)
 			}
-			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
-			instance := result.Instance
-			return result, instance.Status, nil
-		},
-		Timeout:      3 * time.Hour,
-		MinTimeout:   1 * time.Minute,
-		Delay:        3 * time.Minute,
-		PollInterval: 1 * time.Minute,
-	}
-
-	// Wait, catching any errors
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
+			d.SetId("")
+		}
 		return diag.Errorf("error creating instance: %s", err)
 	}
 
-	// Successfully created resource, now set id
-	d.SetId(int64ToString(instance.ID))
+	// Power the instance off when it is not meant to be running
+	diags = append(diags, setNewInstancePowerState(ctx, client, d, instance.ID)...)
+
+	// Successfully created resource
 	resourceVsphereInstanceRead(ctx, d, meta)
 	return diags
 }
 
 func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -484,7 +560,9 @@ func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			log.Printf("[WARN] Resource %s no longer exists, removing from state", d.Id())
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -507,10 +585,24 @@ func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, me
 	d.Set("instance_type_id", instance.InstanceType["id"])
 	d.Set("instance_layout_id", instance.Layout["id"])
 	d.Set("plan_id", instance.Plan.ID)
+	switch instance.Status {
+	case "running", "stopped", "suspended":
+		d.Set("power_state", instance.Status)
+	}
+	if err := setInstanceServicePlanOptions(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+	assignedIPs, runtimeDiags := setInstanceRuntimeAttributes(client, d, resp)
+	diags = append(diags, runtimeDiags...)
+	if runtimeDiags.HasError() {
+		return diags
+	}
 	d.Set("resource_pool_id", instance.Config["resourcePoolId"])
 	d.Set("environment", instance.Environment)
 	d.Set("labels", instance.Labels)
-	d.Set("evar", instance.EnvironmentVariables)
+	d.Set("evar", flattenInstanceEnvironmentVariables(instance.EnvironmentVariables, d.Get("evar").([]interface{})))
+	d.Set("volumes", flattenInstanceVolumes(instance.Volumes))
+	d.Set("interfaces", flattenInstanceInterfaces(instance.Interfaces, assignedIPs))
 	// Tags
 	tags := make(map[string]interface{})
 	if instance.Tags != nil {
@@ -531,18 +623,20 @@ func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, me
 	d.Set("create_user", instance.Config["createUser"])
 	d.Set("asset_tag", instance.Config["smbiosAssetTag"])
 	d.Set("skip_agent_install", instance.Config["noAgent"])
-	if instance.Config["nestedVirtualization"] == "off" {
-		d.Set("nested_virtualization", false)
-	} else {
+	switch instance.Config["nestedVirtualization"] {
+	case "on", true:
 		d.Set("nested_virtualization", true)
+	default:
+		d.Set("nested_virtualization", false)
 	}
-	d.Set("custom_options", instance.Config["customOptions"])
+	d.Set("custom_options", flattenInstanceCustomOptions(instance.Config["customOptions"]))
 	return diags
 }
 
 func resourceVsphereInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
+
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
@@ -573,10 +667,13 @@ func resourceVsphereInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		"name":            name,
 		"description":     description,
 		"labels":          d.Get("labels"),
-		"tags":            tags,
 		"instanceContext": d.Get("environment"),
 		"config":          config,
 	}
+	// unchanged tags are left out so the API keeps them
+	if d.HasChange("tags") {
+		instancePayload["tags"] = tags
+	}
 	payload := map[string]interface{}{
 		"instance": instancePayload,
 	}
@@ -589,13 +686,27 @@ func resourceVsphereInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.UpdateInstanceResult)
 	instance := result.Instance
+
+	// The plan, volumes and network interfaces are changed by resizing the instance
+	if d.HasChanges("plan_id", "cores", "memory", "volumes", "interfaces") {
+		if err := resizeInstance(ctx, client, d, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error reconfiguring instance: %s", err)
+		}
+	}
+
+	if d.HasChange("power_state") {
+		if err := setInstancePowerState(ctx, client, instance.ID, d.Get("power_state").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error changing the power state of instance: %s", err)
+		}
+	}
+
 	// Successfully updated resource, now set id
 	d.SetId(int64ToString(instance.ID))
 	return resourceVsphereInstanceRead(ctx, d, meta)
 }
 
 func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -611,7 +722,8 @@ func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
 			log.Printf("API 404: %s - %s", resp, err)
-			return diag.FromErr(err)
+			d.SetId("")
+			return diags
 		} else {
 			log.Printf("API FAILURE: %s - %s", resp, err)
 			return diag.FromErr(err)
@@ -622,78 +734,58 @@ func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
-func parseNetworkInterfaces(interfaces []interface{}) []map[string]interface{} {
-	var networkInterfaces []map[string]interface{}
-	for i := 0; i < len(interfaces); i++ {
-		row := make(map[string]interface{})
-		item := (interfaces)[i].(map[string]interface{})
-		if item["network_id"] != nil {
-			row["network"] = map[string]interface{}{
-				"id": fmt.Sprintf("network-%d", item["network_id"].(int)),
-			}
-		}
-		if item["ip_address"] != nil {
-			row["ipAddress"] = item["ip_address"] //.(string)
-		}
-		if item["ip_mode"] != nil {
-			row["ipMode"] = item["ip_mode"] // .(string)
-		}
-		if item["network_interface_type_id"] != nil {
-			row["networkInterfaceTypeId"] = item["network_interface_type_id"] //.(int)
-		}
-		networkInterfaces = append(networkInterfaces, row)
+// resourceVsphereInstanceImport imports an existing instance by its ID.
+// Besides what Read returns, it sets the settings that only exist in the
+// configuration to their defaults and the cores and memory of instances
+// customized beyond their service plan, so an adopted instance plans clean.
+func resourceVsphereInstanceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*providerMeta).client
+
+	d.Set("allow_reboot_on_resize", false)
+	d.Set("delete_on_failure", false)
+	if diags := resourceVsphereInstanceRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("error importing instance %s: %s", d.Id(), diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("instance not found")
 	}
-	return networkInterfaces
-}
 
-func parseStorageVolumes(volumes []interface{}) []map[string]interface{} {
-	var storageVolumes []map[string]interface{}
-	for i := 0; i < len(volumes); i++ {
-		row := make(map[string]interface{})
-		item := (volumes)[i].(map[string]interface{})
-		if item["root"] != nil {
-			row["rootVolume"] = item["root"]
-		}
-		if item["name"] != nil {
-			row["name"] = item["name"] // .(string)
-		}
-		if item["size"] != nil {
-			row["size"] = item["size"] // .(int)
-		}
-		if item["size_id"] != nil {
-			row["sizeId"] = item["size_id"] // .(int)
-		}
-		if item["storage_type"] != nil {
-			row["storageType"] = item["storage_type"] // .(int)
-		}
-		if item["datastore_id"] != nil {
-			row["datastoreId"] = item["datastore_id"] // .(int)
-		}
-		storageVolumes = append(storageVolumes, row)
+	resp, err := client.GetInstance(toInt64(d.Id()), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	var payload struct {
+		Instance struct {
+			MaxCores  int `json:"maxCores"`
+			MaxMemory int `json:"maxMemory"`
+		} `json:"instance"`
+	}
+	if err := json.Unmarshal(resp.Body, &payload); err != nil {
+		return nil, err
+	}
+	planResp, err := client.GetPlan(int64(d.Get("plan_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", planResp, err)
+		return nil, err
 	}
-	return storageVolumes // .([]map[string]interface{})
+	var plan MorpheusPlan
+	if err := json.Unmarshal(planResp.Body, &plan); err != nil {
+		return nil, err
+	}
+	if plan.ServicePlan.Customcores && payload.Instance.MaxCores != 0 && payload.Instance.MaxCores != plan.ServicePlan.Maxcores {
+		d.Set("cores", payload.Instance.MaxCores)
+	}
+	if plan.ServicePlan.Custommaxmemory && payload.Instance.MaxMemory != 0 && payload.Instance.MaxMemory != plan.ServicePlan.Maxmemory {
+		d.Set("memory", payload.Instance.MaxMemory)
+	}
+	return []*schema.ResourceData{d}, nil
 }
 
-func parseEnvironmentVariables(variables []interface{}) []map[string]interface{} {
-	var evars []map[string]interface{}
-	// iterate over the array of evars
-	for i := 0; i < len(variables); i++ {
-		row := make(map[string]interface{})
-		evarconfig := variables[i].(map[string]interface{})
-		for k, v := range evarconfig {
-			switch k {
-			case "name":
-				row["name"] = v.(string)
-			case "value":
-				row["value"] = v.(string)
-			case "export":
-				row["export"] = v.(bool)
-			case "masked":
-				row["masked"] = v
-			}
-		}
-		evars = append(evars, row)
-		//log.Printf("evars payload: %s", evars)
-	}
-	return evars
+// suppressProvisioningWorkflowDiff ignores the provisioning workflow of an
+// existing instance when it is not in the state. The workflow only runs
+// when the instance is provisioned and the API does not return it, so it is
+// unknown for imported instances.
+func suppressProvisioningWorkflowDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && (old == "" || old == "0")
 }
@@ -0,0 +1,342 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testVsphereInstanceConfig returns the configuration of a vSphere instance
+// with a root and a data volume, see testInstanceConfig.
+func testVsphereInstanceConfig(s *mockServer) map[string]interface{} {
+	config := testInstanceConfig(s, "created by terraform")
+	delete(config, "config")
+	cloud := config["cloud_id"].(int)
+	s.addCollection(fmt.Sprintf("/api/zones/%d/resource-pools", cloud), "resourcePool", "resourcePools", nil)
+	pool := s.seed(fmt.Sprintf("/api/zones/%d/resource-pools", cloud), map[string]interface{}{"name": "Morpheus-Cluster"})
+	config["resource_pool_id"] = int(pool)
+	config["volumes"] = []interface{}{
+		map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 1, "datastore_id": 5},
+		map[string]interface{}{"name": "data", "size": 50, "storage_type": 1, "datastore_id": 5},
+	}
+	return config
+}
+
+func TestResourceMorpheusVsphereInstance_lifecycle(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	create := testVsphereInstanceConfig(s)
+	update := make(map[string]interface{})
+	for k, v := range create {
+		update[k] = v
+	}
+	update["description"] = "updated by terraform"
+	testResourceLifecycle(t, s, "morpheus_vsphere_instance", "/api/instances", create, update)
+}
+
+func TestResourceMorpheusVsphereInstance_volumes(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	config["allow_reboot_on_resize"] = true
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	record := s.record("/api/instances", toInt64(state.ID))
+	created := record["volumes"].([]interface{})
+	rootID := created[0].(map[string]interface{})["id"]
+	dataID := created[1].(map[string]interface{})["id"]
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+
+	// grow the data volume and add another one
+	config["volumes"] = []interface{}{
+		map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 1, "datastore_id": 5},
+		map[string]interface{}{"name": "data", "size": 100, "storage_type": 1, "datastore_id": 5},
+		map[string]interface{}{"name": "logs", "size": 10, "storage_type": 1, "datastore_id": 5},
+	}
+	state = testResourceApply(t, meta, "morpheus_vsphere_instance", state, config)
+	requests := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 resize request, got %d", len(requests))
+	}
+	volumes := requests[0].Body["volumes"].([]interface{})
+	if len(volumes) != 3 {
+		t.Fatalf("expected 3 volumes, got %v", volumes)
+	}
+	data := volumes[1].(map[string]interface{})
+	if data["id"] != dataID || data["size"] != float64(100) {
+		t.Fatalf("expected data volume %v to be resized, got %v", dataID, data)
+	}
+	if volumes[2].(map[string]interface{})["id"] != float64(-1) {
+		t.Fatalf("expected new logs volume, got %v", volumes[2])
+	}
+	state = testResourceRefresh(t, meta, "morpheus_vsphere_instance", state)
+	if state.Attributes["volumes.#"] != "3" || state.Attributes["volumes.1.size"] != "100" || state.Attributes["volumes.2.name"] != "logs" {
+		t.Fatalf("unexpected volumes in state: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+
+	// removing the data volumes keeps the root volume
+	state = testResourceApply(t, meta, "morpheus_vsphere_instance", state, map[string]interface{}{
+		"name":                   config["name"],
+		"description":            config["description"],
+		"cloud_id":               config["cloud_id"],
+		"group_id":               config["group_id"],
+		"instance_type_id":       config["instance_type_id"],
+		"instance_layout_id":     config["instance_layout_id"],
+		"plan_id":                config["plan_id"],
+		"resource_pool_id":       config["resource_pool_id"],
+		"allow_reboot_on_resize": true,
+		"volumes": []interface{}{
+			map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 1, "datastore_id": 5},
+		},
+	})
+	requests = s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")
+	volumes = requests[1].Body["volumes"].([]interface{})
+	if len(volumes) != 1 || volumes[0].(map[string]interface{})["id"] != rootID {
+		t.Fatalf("expected only the root volume %v to be kept, got %v", rootID, volumes)
+	}
+
+	// leaving the volumes out of the configuration keeps them as they are
+	delete(config, "volumes")
+	state = testResourceApply(t, meta, "morpheus_vsphere_instance", state, config)
+	if requests := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize"); len(requests) != 2 {
+		t.Fatalf("expected no resize request, got %d", len(requests)-2)
+	}
+	if state.Attributes["volumes.#"] != "1" {
+		t.Fatalf("expected the root volume to stay in state, got %v", state.Attributes)
+	}
+}
+
+func TestResourceMorpheusVsphereInstance_assignedIP(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	config["interfaces"] = []interface{}{
+		map[string]interface{}{"network_id": 12},
+		map[string]interface{}{"network_id": 14, "ip_mode": "static", "ip_address": "10.0.1.14"},
+	}
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	if state.Attributes["interfaces.0.assigned_ip"] != "10.0.0.100" || state.Attributes["interfaces.1.assigned_ip"] != "10.0.1.14" {
+		t.Fatalf("unexpected assigned addresses: %v", state.Attributes)
+	}
+	if state.Attributes["primary_ip_address"] != "10.0.0.100" || state.Attributes["ip_addresses.#"] != "2" {
+		t.Fatalf("unexpected addresses: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+}
+
+func TestResourceMorpheusVsphereInstance_assignedIPSameNetwork(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	config["interfaces"] = []interface{}{
+		map[string]interface{}{"network_id": 12},
+		map[string]interface{}{"network_id": 12, "ip_mode": "static", "ip_address": "10.0.0.50"},
+	}
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	if state.Attributes["interfaces.0.assigned_ip"] != "10.0.0.100" || state.Attributes["interfaces.1.assigned_ip"] != "10.0.0.50" {
+		t.Fatalf("expected each interface to get its own address, got %v", state.Attributes)
+	}
+}
+
+func TestResourceMorpheusVsphereInstance_serverNotFound(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	record := s.record("/api/instances", toInt64(state.ID))
+	s.remove("/api/servers", toInt64(fmt.Sprint(record["servers"].([]interface{})[0])))
+
+	newState, diags := testResource(t, "morpheus_vsphere_instance").RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("expected a missing server not to fail the refresh, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "not found") {
+		t.Fatalf("expected a warning about the missing server, got %v", diags)
+	}
+	if newState == nil || newState.Attributes["status"] != "running" {
+		t.Fatalf("expected the instance to be read, got %v", newState)
+	}
+}
+
+func TestResourceMorpheusVsphereInstance_interfaces(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	config["allow_reboot_on_resize"] = true
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	record := s.record("/api/instances", toInt64(state.ID))
+	nicID := record["interfaces"].([]interface{})[0].(map[string]interface{})["id"]
+
+	config["interfaces"] = []interface{}{
+		map[string]interface{}{"network_id": 12},
+		map[string]interface{}{"network_id": 14, "ip_mode": "static", "ip_address": "10.0.0.14"},
+	}
+	// adding an interface goes through the resize, which may reboot the instance
+	config["allow_reboot_on_resize"] = false
+	if err := testResourcePlanError(t, meta, "morpheus_vsphere_instance", state, config); !strings.Contains(err, "allow_reboot_on_resize") {
+		t.Fatalf("expected adding an interface to require allow_reboot_on_resize, got %q", err)
+	}
+	config["allow_reboot_on_resize"] = true
+	state = testResourceApply(t, meta, "morpheus_vsphere_instance", state, config)
+	interfaces := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")[0].Body["networkInterfaces"].([]interface{})
+	if len(interfaces) != 2 || interfaces[0].(map[string]interface{})["id"] != nicID {
+		t.Fatalf("expected existing interface %v to be kept, got %v", nicID, interfaces)
+	}
+	if _, ok := interfaces[1].(map[string]interface{})["id"]; ok {
+		t.Fatalf("expected new interface without id, got %v", interfaces[1])
+	}
+
+	state = testResourceRefresh(t, meta, "morpheus_vsphere_instance", state)
+	if state.Attributes["interfaces.#"] != "2" || state.Attributes["interfaces.1.network_id"] != "14" || state.Attributes["interfaces.1.ip_address"] != "10.0.0.14" {
+		t.Fatalf("unexpected interfaces in state: %v", state.Attributes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+}
+
+func TestResourceMorpheusVsphereInstance_plan(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	plan := s.seed("/api/service-plans", map[string]interface{}{"name": "2 CPU, 8GB Memory", "code": "vm-2048"})
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	config["plan_id"] = int(plan)
+	config["allow_reboot_on_resize"] = true
+	newState := testResourceApply(t, meta, "morpheus_vsphere_instance", state, config)
+	if newState.ID != state.ID {
+		t.Fatalf("expected instance %s to be resized in place, got %s", state.ID, newState.ID)
+	}
+	body := s.requestsFor("PUT", "/api/instances/"+state.ID+"/resize")[0].Body
+	volumes := body["volumes"].([]interface{})
+	if len(volumes) != 2 {
+		t.Fatalf("expected the current volumes to be kept, got %v", volumes)
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", testResourceRefresh(t, meta, "morpheus_vsphere_instance", newState), config)
+}
+
+func TestResourceMorpheusVsphereInstance_powerState(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+
+	state := testResourceApply(t, meta, "morpheus_vsphere_instance", nil, config)
+	if state.Attributes["power_state"] != "running" {
+		t.Fatalf("expected power_state running, got %q", state.Attributes["power_state"])
+	}
+
+	config["power_state"] = "suspended"
+	state = testResourceApply(t, meta, "morpheus_vsphere_instance", state, config)
+	if len(s.requestsFor("PUT", "/api/instances/"+state.ID+"/suspend")) != 1 {
+		t.Fatal("expected the instance to be suspended")
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+}
+
+func TestResourceMorpheusVsphereInstance_failedProvision(t *testing.T) {
+	testFastInstanceProvision(t)
+	s := newMockServer(t)
+	id := testMockFailedProvision(s)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+
+	if err := testResourceApplyError(t, meta, "morpheus_vsphere_instance", nil, config); !strings.Contains(err, "is failed: Failed to clone template") {
+		t.Fatalf("expected the provisioning error, got %q", err)
+	}
+	if s.record("/api/instances", *id) == nil {
+		t.Fatal("expected the failed instance to be kept")
+	}
+}
+
+func TestResourceMorpheusVsphereInstance_import(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	config := testVsphereInstanceConfig(s)
+	plan := s.seed("/api/service-plans", map[string]interface{}{"name": "Custom", "code": "vm-custom", "customCores": true, "maxCores": 1})
+
+	// an instance provisioned outside of terraform, as returned by the API
+	id := s.seed("/api/instances", map[string]interface{}{
+		"name":            "brownfield",
+		"description":     "adopted by terraform",
+		"status":          "running",
+		"cloud":           map[string]interface{}{"id": config["cloud_id"]},
+		"group":           map[string]interface{}{"id": config["group_id"]},
+		"instanceType":    map[string]interface{}{"id": config["instance_type_id"]},
+		"layout":          map[string]interface{}{"id": config["instance_layout_id"]},
+		"plan":            map[string]interface{}{"id": plan},
+		"maxCores":        4,
+		"instanceContext": "dev",
+		"labels":          []interface{}{"demo", "terraform"},
+		"tags":            []interface{}{map[string]interface{}{"name": "owner", "value": "terraform"}},
+		"evars": []interface{}{
+			map[string]interface{}{"name": "application", "value": "demo", "export": true, "masked": false, "scope": "instance"},
+			map[string]interface{}{"name": "password", "value": "************", "export": false, "masked": true, "scope": "instance"},
+		},
+		"volumes": []interface{}{
+			map[string]interface{}{"id": 31, "name": "root", "rootVolume": true, "size": 20, "storageType": 1, "datastoreId": 5},
+			map[string]interface{}{"id": 32, "name": "data", "rootVolume": false, "size": 50, "storageType": 1, "datastoreId": 6},
+		},
+		"interfaces": []interface{}{
+			map[string]interface{}{"id": 41, "network": map[string]interface{}{"id": "network-12"}, "ipMode": "dhcp", "networkInterfaceTypeId": 4},
+		},
+		"config": map[string]interface{}{
+			"resourcePoolId":       config["resource_pool_id"],
+			"customOptions":        map[string]interface{}{"tier": "web", "replicas": 2},
+			"createUser":           true,
+			"smbiosAssetTag":       "ASSET-1",
+			"noAgent":              false,
+			"nestedVirtualization": "off",
+		},
+	})
+
+	state := testResourceImport(t, meta, "morpheus_vsphere_instance", int64ToString(id))
+	if state.Attributes["volumes.1.datastore_id"] != "6" || state.Attributes["interfaces.0.network_id"] != "12" {
+		t.Fatalf("unexpected volumes or interfaces: %v", state.Attributes)
+	}
+	if state.Attributes["evar.#"] != "2" || state.Attributes["custom_options.replicas"] != "2" || state.Attributes["cores"] != "4" {
+		t.Fatalf("unexpected imported state: %v", state.Attributes)
+	}
+
+	config["name"] = "brownfield"
+	config["description"] = "adopted by terraform"
+	config["plan_id"] = int(plan)
+	config["cores"] = 4
+	config["volumes"] = []interface{}{
+		map[string]interface{}{"root": true, "name": "root", "size": 20, "storage_type": 1, "datastore_id": 5},
+		map[string]interface{}{"name": "data", "size": 50, "storage_type": 1, "datastore_id": 6},
+	}
+	config["interfaces"] = []interface{}{
+		map[string]interface{}{"network_id": 12, "ip_mode": "dhcp", "network_interface_type_id": 4},
+	}
+	config["evar"] = []interface{}{
+		map[string]interface{}{"name": "application", "value": "demo", "export": true, "masked": false},
+		map[string]interface{}{"name": "password", "value": "s3cret", "export": false, "masked": true},
+	}
+	config["custom_options"] = map[string]interface{}{"tier": "web", "replicas": "2"}
+	config["create_user"] = true
+	config["asset_tag"] = "ASSET-1"
+	config["workflow_name"] = "bootstrap"
+	testResourcePlanEmpty(t, meta, "morpheus_vsphere_instance", state, config)
+}
+
+func TestResourceMorpheusVsphereInstance_createTimeout(t *testing.T) {
+	timeouts := testResource(t, "morpheus_vsphere_instance").Timeouts
+	if timeouts == nil || timeouts.Create == nil || *timeouts.Create != 3*time.Hour {
+		t.Fatalf("expected a default create timeout of 3 hours, got %v", timeouts)
+	}
+}
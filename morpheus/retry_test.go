@@ -0,0 +1,116 @@
+package morpheus
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+func testRetryClient(t *testing.T, s *mockServer, maxRetries int) *morpheus.Client {
+	t.Helper()
+	return testConfigClient(t, &Config{
+		Url:          s.URL,
+		AccessToken:  "mock-access-token",
+		MaxRetries:   maxRetries,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	})
+}
+
+func TestRetryTransport_retriesIdempotentRequests(t *testing.T) {
+	s := newMockServer(t)
+	s.failNext("GET", "/api/groups", http.StatusBadGateway, 2)
+	client := testRetryClient(t, s, 3)
+
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := len(s.requestsFor("GET", "/api/groups")); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryTransport_doesNotRetryCreate(t *testing.T) {
+	s := newMockServer(t)
+	s.failNext("POST", "/api/groups", http.StatusBadGateway, 1)
+	client := testRetryClient(t, s, 3)
+
+	_, err := client.CreateGroup(&morpheus.Request{
+		Body: map[string]interface{}{"group": map[string]interface{}{"name": "test"}},
+	})
+	if err == nil {
+		t.Fatal("expected error creating group")
+	}
+	if got := len(s.requestsFor("POST", "/api/groups")); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryTransport_retriesTooManyRequests(t *testing.T) {
+	s := newMockServer(t)
+	s.failNext("POST", "/api/groups", http.StatusTooManyRequests, 1)
+	client := testRetryClient(t, s, 3)
+
+	_, err := client.CreateGroup(&morpheus.Request{
+		Body: map[string]interface{}{"group": map[string]interface{}{"name": "test"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	requests := s.requestsFor("POST", "/api/groups")
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if name := requests[1].Body["group"].(map[string]interface{})["name"]; name != "test" {
+		t.Fatalf("expected the body to be sent again, got %v", requests[1].Body)
+	}
+}
+
+func TestRetryTransport_exhausted(t *testing.T) {
+	s := newMockServer(t)
+	s.failNext("GET", "/api/groups", http.StatusServiceUnavailable, 10)
+	client := testRetryClient(t, s, 2)
+
+	if _, err := client.ListGroups(&morpheus.Request{}); err == nil {
+		t.Fatal("expected error listing groups")
+	}
+	if got := len(s.requestsFor("GET", "/api/groups")); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryTransport_disabled(t *testing.T) {
+	s := newMockServer(t)
+	s.failNext("GET", "/api/groups", http.StatusBadGateway, 1)
+	client := testRetryClient(t, s, 0)
+
+	if _, err := client.ListGroups(&morpheus.Request{}); err == nil {
+		t.Fatal("expected error listing groups")
+	}
+	if got := len(s.requestsFor("GET", "/api/groups")); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	rt := &retryTransport{waitMin: time.Second, waitMax: 10 * time.Second}
+
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := rt.backoff(attempt, nil)
+		if wait < max/2 || wait > max {
+			t.Fatalf("attempt %d: expected wait between %s and %s, got %s", attempt, max/2, max, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if wait := rt.backoff(0, resp); wait != 3*time.Second {
+		t.Fatalf("expected Retry-After to be honored, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := rt.backoff(0, resp); wait != 10*time.Second {
+		t.Fatalf("expected Retry-After to be capped at retry_wait_max, got %s", wait)
+	}
+}
@@ -0,0 +1,72 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// requireTenantSupport makes the resources without a tenant_id attribute
+// fail at plan time when the provider is scoped to a subtenant. The API
+// endpoints behind them do not take a tenant, a master tenant user would
+// manage the objects of the master tenant instead.
+func requireTenantSupport(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if _, ok := r.Schema["tenant_id"]; ok {
+			continue
+		}
+		name, customizeDiff := name, r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if tenantID := providerTenantID(meta); tenantID != 0 {
+				return fmt.Errorf("%s can not be managed in a subtenant, the provider is scoped to tenant %d: use a provider without tenant_id and tenant_name for this resource", name, tenantID)
+			}
+			if customizeDiff != nil {
+				return customizeDiff(ctx, d, meta)
+			}
+			return nil
+		}
+	}
+}
+
+// providerTenantID returns the id of the tenant the provider is scoped to,
+// or 0 when it operates in the tenant of the authenticated user.
+func providerTenantID(meta interface{}) int64 {
+	return meta.(*providerMeta).tenantID
+}
+
+// findTenantID looks up the id of the tenant with the given name.
+func findTenantID(client *morpheus.Client, name string) (int64, error) {
+	resp, err := client.ListTenants(&morpheus.Request{
+		QueryParams: map[string]string{
+			"name": name,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error looking up tenant %q: %s", name, err)
+	}
+	result := resp.Result.(*morpheus.ListTenantsResult)
+	if result.Accounts != nil {
+		for _, tenant := range *result.Accounts {
+			if tenant.Name == name {
+				return tenant.ID, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("tenant %q not found", name)
+}
+
+// resourceTenantID returns the tenant_id set on the resource, falling back
+// to the tenant the provider is scoped to.
+func resourceTenantID(d *schema.ResourceData, meta interface{}) int64 {
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		switch v := tenantID.(type) {
+		case int:
+			return int64(v)
+		case string:
+			return toInt64(v)
+		}
+	}
+	return providerTenantID(meta)
+}
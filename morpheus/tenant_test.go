@@ -0,0 +1,184 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testTenantProviderMeta configures the provider against the mock server
+// scoped to a subtenant with the given provider settings.
+func testTenantProviderMeta(t *testing.T, s *mockServer, tenant map[string]interface{}) (interface{}, error) {
+	t.Helper()
+	p := Provider()
+	raw := map[string]interface{}{
+		"url":          s.URL,
+		"access_token": "mock-access-token",
+	}
+	for k, v := range tenant {
+		raw[k] = v
+	}
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		return nil, fmt.Errorf("%v", diags)
+	}
+	return p.Meta(), nil
+}
+
+func TestProvider_tenantID(t *testing.T) {
+	s := newMockServer(t)
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_id": 7})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+	if got := providerTenantID(meta); got != 7 {
+		t.Fatalf("expected tenant 7, got %d", got)
+	}
+
+}
+
+func TestProvider_tenantUnsupportedResource(t *testing.T) {
+	s := newMockServer(t)
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_id": 7})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+
+	// groups can not be assigned to a tenant, they must not silently end
+	// up in the master tenant
+	config := map[string]interface{}{
+		"name": "tfexample",
+	}
+	msg := testResourcePlanError(t, meta, "morpheus_group", nil, config)
+	if !strings.Contains(msg, "morpheus_group can not be managed in a subtenant") {
+		t.Fatalf("unexpected error: %s", msg)
+	}
+	if requests := s.requestsFor("POST", "/api/groups"); len(requests) != 0 {
+		t.Fatalf("expected no group to be created, got %#v", requests)
+	}
+
+	// the resources keep working without a provider tenant
+	testResourceApply(t, testProviderMeta(t, s), "morpheus_group", nil, config)
+}
+
+func TestProvider_tenantName(t *testing.T) {
+	s := newMockServer(t)
+	s.seed("/api/accounts", map[string]interface{}{"name": "other"})
+	id := s.seed("/api/accounts", map[string]interface{}{"name": "acme"})
+
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_name": "acme"})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+	if got := providerTenantID(meta); got != id {
+		t.Fatalf("expected tenant %d, got %d", id, got)
+	}
+	for _, r := range s.requestsFor("GET", "/api/accounts") {
+		if strings.Contains(r.Query, "accountId") {
+			t.Fatalf("tenant lookup should not be scoped, got %q", r.Query)
+		}
+	}
+
+	if _, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_name": "missing"}); err == nil || !strings.Contains(err.Error(), `tenant "missing" not found`) {
+		t.Fatalf("expected tenant not found error, got %v", err)
+	}
+}
+
+func TestProvider_noTenant(t *testing.T) {
+	s := newMockServer(t)
+	meta := testProviderMeta(t, s)
+	if got := providerTenantID(meta); got != 0 {
+		t.Fatalf("expected no tenant, got %d", got)
+	}
+	if _, err := meta.(*providerMeta).client.ListInstances(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests := s.requestsFor("GET", "/api/instances"); requests[0].Query != "" {
+		t.Fatalf("expected unscoped request, got %q", requests[0].Query)
+	}
+}
+
+func TestResourcePrice_providerTenant(t *testing.T) {
+	s := newMockServer(t)
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_id": 7})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+
+	config := map[string]interface{}{
+		"name":          "tfexample",
+		"code":          "tfexample",
+		"price_type":    "fixed",
+		"price_unit":    "month",
+		"incur_charges": "always",
+		"currency":      "USD",
+		"cost":          10.0,
+		"markup_type":   "fixed",
+	}
+	state := testResourceApply(t, meta, "morpheus_price", nil, config)
+	account := s.record("/api/prices", toInt64(state.ID))["account"].(map[string]interface{})
+	if account["id"] != float64(7) {
+		t.Fatalf("expected price assigned to tenant 7, got %v", account)
+	}
+
+	// without a tenant the price is not assigned to one
+	state = testResourceApply(t, testProviderMeta(t, s), "morpheus_price", nil, config)
+	if account := s.record("/api/prices", toInt64(state.ID))["account"]; account != nil {
+		t.Fatalf("expected price without tenant, got %v", account)
+	}
+}
+
+func TestResourceMorpheusUser_providerTenant(t *testing.T) {
+	s := newMockServer(t)
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_id": 7})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+	s.addCollection("/api/accounts/7/users", "user", "users", nil)
+
+	config := testUserConfig()
+	state := testResourceApply(t, meta, "morpheus_user", nil, config)
+	if s.record("/api/accounts/7/users", toInt64(state.ID)) == nil {
+		t.Fatalf("user %s was not created in tenant 7", state.ID)
+	}
+	state = testResourceRefresh(t, meta, "morpheus_user", state)
+	testResourcePlanEmpty(t, meta, "morpheus_user", state, config)
+	testResourceDestroy(t, meta, "morpheus_user", state)
+	if s.record("/api/accounts/7/users", toInt64(state.ID)) != nil {
+		t.Fatalf("user %s was not deleted from tenant 7", state.ID)
+	}
+}
+
+func TestResourceNetworkDomain_providerTenant(t *testing.T) {
+	s := newMockServer(t)
+	meta, err := testTenantProviderMeta(t, s, map[string]interface{}{"tenant_id": 7})
+	if err != nil {
+		t.Fatalf("error configuring provider: %s", err)
+	}
+
+	config := map[string]interface{}{
+		"name": "tfexample.local",
+	}
+	state := testResourceApply(t, meta, "morpheus_network_domain", nil, config)
+	account := s.record("/api/networks/domains", toInt64(state.ID))["account"].(map[string]interface{})
+	if account["id"] != float64(7) {
+		t.Fatalf("expected network domain assigned to tenant 7, got %v", account)
+	}
+	state = testResourceRefresh(t, meta, "morpheus_network_domain", state)
+	if state.Attributes["tenant_id"] != "7" {
+		t.Fatalf("expected tenant_id 7, got %q", state.Attributes["tenant_id"])
+	}
+	testResourcePlanEmpty(t, meta, "morpheus_network_domain", state, config)
+
+	// the resource setting overrides the provider tenant
+	config["tenant_id"] = 9
+	state = testResourceApply(t, meta, "morpheus_network_domain", state, config)
+	account = s.record("/api/networks/domains", toInt64(state.ID))["account"].(map[string]interface{})
+	if account["id"] != float64(9) {
+		t.Fatalf("expected network domain assigned to tenant 9, got %v", account)
+	}
+}
@@ -0,0 +1,160 @@
+package morpheus
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// The morpheus-go-sdk client builds a new HTTP client for every request
+// and always skips TLS verification, which leaves no way to hand it a
+// configured transport. Instead, the SDK client is pointed at a relay
+// listening on the loopback interface and the relay forwards each request
+// to the appliance using a transport built from the provider settings.
+// Only requests under a random path prefix known to the SDK client are
+// forwarded, so other local processes can not use the relay.
+
+// apiRelay forwards requests from the SDK client to the Morpheus appliance.
+type apiRelay struct {
+	URL    string
+	server *http.Server
+}
+
+// newAPIRelay starts a relay forwarding requests to target through the
+// given transport.
+func newAPIRelay(target *url.URL, transport http.RoundTripper) (*apiRelay, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("error generating API relay token: %s", err)
+	}
+	prefix := "/" + hex.EncodeToString(token)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error starting API relay: %s", err)
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path = strings.TrimSuffix(target.Path, "/") + strings.TrimPrefix(req.URL.Path, prefix)
+			req.URL.RawPath = ""
+			req.Host = target.Host
+			// the relay is an implementation detail, do not advertise it
+			req.Header["X-Forwarded-For"] = nil
+		},
+		Transport: transport,
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			log.Printf("API FAILURE: %s %s - %s", req.Method, req.URL.Path, err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"msg":     err.Error(),
+			})
+		},
+	}
+
+	relay := &apiRelay{
+		URL: "http://" + listener.Addr().String() + prefix,
+		server: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if !strings.HasPrefix(req.URL.Path, prefix+"/") {
+				http.NotFound(w, req)
+				return
+			}
+			proxy.ServeHTTP(w, req)
+		})},
+	}
+	go relay.server.Serve(listener)
+	return relay, nil
+}
+
+// Close stops the relay.
+func (r *apiRelay) Close() error {
+	return r.server.Close()
+}
+
+// transport builds the HTTP transport used to talk to the appliance from
+// the TLS and proxy settings of the provider.
+func (c *Config) transport() (*http.Transport, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CACertFile != "" || c.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.Printf("[WARN] Unable to load system certificate pool: %s", err)
+			pool = x509.NewCertPool()
+		}
+		if c.CACertFile != "" {
+			pem, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading ca_cert_file: %s", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in ca_cert_file %s", c.CACertFile)
+			}
+		}
+		if c.CACertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+				return nil, fmt.Errorf("no certificates found in ca_cert_pem")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be specified together")
+		}
+		certPEM, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_cert: %s", err)
+		}
+		keyPEM, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_key: %s", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy_url: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// readPEM returns value as is when it contains PEM encoded data,
+// otherwise it is treated as the path of a file to read.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
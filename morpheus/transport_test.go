@@ -0,0 +1,201 @@
+package morpheus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+func testTLSServer(t *testing.T, configure func(*httptest.Server)) *httptest.Server {
+	t.Helper()
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"groups": []interface{}{}})
+	}))
+	if configure != nil {
+		configure(s)
+	}
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func testConfigClient(t *testing.T, config *Config) *morpheus.Client {
+	t.Helper()
+	client, diags := config.Client()
+	if diags.HasError() {
+		t.Fatalf("error configuring client: %v", diags)
+	}
+	t.Cleanup(func() { config.relay.Close() })
+	return client
+}
+
+func testServerCAPEM(s *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+}
+
+func TestConfigClient_verifiesTLS(t *testing.T) {
+	s := testTLSServer(t, nil)
+	client := testConfigClient(t, &Config{Url: s.URL, AccessToken: "token"})
+	_, err := client.ListGroups(&morpheus.Request{})
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("expected certificate verification error, got %v", err)
+	}
+}
+
+func TestConfigClient_insecure(t *testing.T) {
+	s := testTLSServer(t, nil)
+	client := testConfigClient(t, &Config{Url: s.URL, AccessToken: "token", Insecure: true})
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestConfigClient_caCertPEM(t *testing.T) {
+	s := testTLSServer(t, nil)
+	client := testConfigClient(t, &Config{Url: s.URL, AccessToken: "token", CACertPEM: testServerCAPEM(s)})
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestConfigClient_clientCertificate(t *testing.T) {
+	certPEM, keyPEM, cert := testClientCertificate(t)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	s := testTLSServer(t, func(s *httptest.Server) {
+		s.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	})
+
+	client := testConfigClient(t, &Config{Url: s.URL, AccessToken: "token", CACertPEM: testServerCAPEM(s)})
+	if _, err := client.ListGroups(&morpheus.Request{}); err == nil {
+		t.Fatalf("expected error without a client certificate")
+	}
+
+	client = testConfigClient(t, &Config{
+		Url:         s.URL,
+		AccessToken: "token",
+		CACertPEM:   testServerCAPEM(s),
+		ClientCert:  certPEM,
+		ClientKey:   keyPEM,
+	})
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestConfigClient_proxyURL(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"groups": []interface{}{}})
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := testConfigClient(t, &Config{Url: "http://morpheus.example.com", AccessToken: "token", ProxyURL: proxy.URL})
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://morpheus.example.com/api/groups" {
+		t.Fatalf("expected request through proxy, got %v", proxied)
+	}
+}
+
+func TestConfigClient_relayToken(t *testing.T) {
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"groups": []interface{}{}})
+	}))
+	t.Cleanup(s.Close)
+
+	config := &Config{Url: s.URL, AccessToken: "token"}
+	client := testConfigClient(t, config)
+	if _, err := client.ListGroups(&morpheus.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	relayURL, err := url.Parse(config.relay.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get("http://" + relayURL.Host + "/api/groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected requests without the relay token to be rejected, got HTTP %d", resp.StatusCode)
+	}
+	if len(requests) != 1 || requests[0] != "/api/groups" {
+		t.Fatalf("expected only the SDK request to be forwarded, got %v", requests)
+	}
+
+	config.relay.Close()
+	if _, err := client.ListGroups(&morpheus.Request{}); err == nil {
+		t.Fatalf("expected error after closing the relay")
+	}
+}
+
+func TestConfigClient_invalidSettings(t *testing.T) {
+	cases := map[string]*Config{
+		"url":         {Url: "morpheus.example.com"},
+		"ca_cert_pem": {Url: "https://morpheus.example.com", CACertPEM: "not a certificate"},
+		"client_key":  {Url: "https://morpheus.example.com", ClientCert: "-----BEGIN CERTIFICATE-----"},
+		"proxy_url":   {Url: "https://morpheus.example.com", ProxyURL: "://proxy"},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, diags := config.Client(); !diags.HasError() {
+				t.Fatalf("expected error for invalid %s", name)
+			}
+		})
+	}
+}
+
+// testClientCertificate generates a self signed client certificate.
+func testClientCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM), cert
+}